@@ -0,0 +1,235 @@
+/* ============================================================================
+
+    Eksport binarny
+    Zapis fontu jako surowy plik .bin (np. do zewnętrznej pamięci SPI flash)
+    oraz mały nagłówek C z opisem bloku danych
+    – buildBlob, generateBlobHeader, saveBinaryDialog
+
+    Każdy glif zajmuje "stride" bajtów: dane wierszy + dopełnienie zerami
+    do wybranego wyrównania. Makro adresu znaku GLYPH_ADDR(c) powstaje
+    tylko dla ciągłego zakresu kodów - w przeciwnym razie nagłówek dostaje
+    tabelę kodów i makro adresu glifu o numerze GLYPH_ADDR_AT(i).
+
+=========================================================================== */
+
+package main
+
+import (
+	"fmt"
+	"hash/crc32"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Układy bajtów w pliku binarnym
+const (
+	layoutU16LE = "uint16 LE"
+	layoutU16BE = "uint16 BE"
+	layoutHLSB  = "MONO_HLSB"
+)
+
+var binLayouts = []string{layoutU16LE, layoutU16BE, layoutHLSB}
+
+// Dostępne wyrównania glifu w bajtach
+var binAligns = []string{"1", "2", "4", "8", "16", "32", "64", "256"}
+
+// binRowBytes zwraca liczbę bajtów jednego wiersza w danym układzie
+func binRowBytes(f *Font, layout string) int {
+	if layout == layoutHLSB {
+		return (f.Width + 7) / 8
+	}
+	return 2
+}
+
+// binStride zwraca rozmiar glifu w bajtach po wyrównaniu
+func binStride(f *Font, layout string, align int) int {
+	size := binRowBytes(f, layout) * f.Height
+	if align > 1 && size%align != 0 {
+		size += align - size%align
+	}
+	return size
+}
+
+// buildBlob buduje blok danych zapisywanych glifów w wybranym układzie i wyrównaniu
+func buildBlob(f *Font, opt exportOptions, layout string, align int) []byte {
+	indices := exportIndices(f, opt)
+	stride := binStride(f, layout, align)
+	rowBytes := binRowBytes(f, layout)
+	pad := rowBytes*8 - f.Width
+
+	blob := make([]byte, len(indices)*stride)
+	for n, i := range indices {
+		base := n * stride
+		for y, row := range f.Glyph(i).Rows() {
+			off := base + y*rowBytes
+			switch layout {
+			case layoutU16LE:
+				blob[off] = byte(row)
+				blob[off+1] = byte(row >> 8)
+			case layoutU16BE:
+				blob[off] = byte(row >> 8)
+				blob[off+1] = byte(row)
+			default:
+				// wiersz wyrównany do lewej, MSB first
+				v := uint32(row) << pad
+				for b := 0; b < rowBytes; b++ {
+					blob[off+b] = byte(v >> (8 * (rowBytes - 1 - b)))
+				}
+			}
+		}
+	}
+	return blob
+}
+
+// generateBlobHeader generuje nagłówek C opisujący blok binarny
+func generateBlobHeader(f *Font, opt exportOptions, binName, layout string, align int, offset uint32, blob []byte, withCRC bool) string {
+	var sb strings.Builder
+	name := strings.ToUpper(f.BaseName()) + "_BIN"
+	guard := name + "_H"
+
+	sb.WriteString(fmt.Sprintf(T("generatedAuto"), versionApp))
+	sb.WriteString(T("charSize"))
+	sb.WriteString(fmt.Sprintf("%dx%d\n", f.Width, f.Height))
+	sb.WriteString(fmt.Sprintf("// %s, %s: %s\n\n", binName, T("binLayout"), layout))
+
+	sb.WriteString("#ifndef " + guard + "\n")
+	sb.WriteString("#define " + guard + "\n\n")
+	sb.WriteString("#include <stdint.h>\n\n")
+
+	def := func(key, value string) {
+		sb.WriteString(fmt.Sprintf("#define %-28s %s\n", name+"_"+key, value))
+	}
+	def("OFFSET", fmt.Sprintf("0x%08Xu", offset))
+	def("WIDTH", fmt.Sprintf("%du", f.Width))
+	def("HEIGHT", fmt.Sprintf("%du", f.Height))
+	def("ROW_BYTES", fmt.Sprintf("%du", binRowBytes(f, layout)))
+	def("GLYPH_BYTES", fmt.Sprintf("%du", binRowBytes(f, layout)*f.Height))
+	def("GLYPH_SIZE", fmt.Sprintf("%du", binStride(f, layout, align)))
+	def("GLYPH_COUNT", fmt.Sprintf("%du", len(exportIndices(f, opt))))
+	def("FIRST_CHAR", fmt.Sprintf("%du", exportFirstChar(f, opt)))
+	def("SIZE", fmt.Sprintf("%du", len(blob)))
+	if withCRC {
+		def("CRC32", fmt.Sprintf("0x%08Xu", crc32.ChecksumIEEE(blob)))
+	}
+	sb.WriteString("\n")
+	if exportContiguous(f, opt) {
+		sb.WriteString(fmt.Sprintf("#define %s_GLYPH_ADDR(c) (%s_OFFSET + ((uint32_t)(c) - %s_FIRST_CHAR) * %s_GLYPH_SIZE)\n\n",
+			name, name, name, name))
+	} else {
+		// kody nieciągłe - glif i ma kod CODES[i]
+		sb.WriteString(fmt.Sprintf("static const uint16_t %s_CODES[] = { %s };\n", name, joinInts(exportCodes(f, opt))))
+		sb.WriteString(fmt.Sprintf("#define %s_GLYPH_ADDR_AT(i) (%s_OFFSET + (uint32_t)(i) * %s_GLYPH_SIZE)\n\n",
+			name, name, name))
+	}
+
+	sb.WriteString("#endif // " + guard + "\n")
+	return sb.String()
+}
+
+// Wywoływane przy kliknięciu "Eksport .bin"
+func saveBinaryDialog(w fyne.Window, f *Font) {
+	if f.Empty() {
+		dialog.ShowInformation(T("noData"), T("loadFirst"), w)
+		return
+	}
+	if f.RGB565() {
+		dialog.ShowInformation(T("saveBinary"), T("binIconUnsupported"), w)
+		return
+	}
+	if f.Depth > 1 {
+		dialog.ShowInformation(T("saveBinary"), T("binGrayUnsupported"), w)
+		return
+	}
+
+	layoutSelect := widget.NewSelect(binLayouts, nil)
+	layoutSelect.SetSelected(layoutU16LE)
+	alignSelect := widget.NewSelect(binAligns, nil)
+	alignSelect.SetSelected("1")
+	offsetEntry := widget.NewEntry()
+	offsetEntry.SetText("0x00000000")
+	crcCheck := widget.NewCheck("", nil)
+	crcCheck.SetChecked(true)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(T("binLayout"), layoutSelect),
+		widget.NewFormItem(T("binAlign"), alignSelect),
+		widget.NewFormItem(T("binOffset"), offsetEntry),
+		widget.NewFormItem(T("binCRC"), crcCheck),
+	}
+
+	dialog.ShowForm(T("saveBinary"), T("saveAction"), T("cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		layout := layoutSelect.Selected
+		align, _ := strconv.Atoi(alignSelect.Selected)
+		offset, err := strconv.ParseUint(strings.TrimSpace(offsetEntry.Text), 0, 32)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("%s: %w", T("binOffset"), err), w)
+			return
+		}
+		withCRC := crcCheck.Checked
+
+		fd := dialog.NewFileSave(func(uc fyne.URIWriteCloser, _ error) {
+			if uc == nil {
+				return
+			}
+			defer func() { _ = uc.Close() }()
+
+			var opt exportOptions // cały font
+			blob := buildBlob(f, opt, layout, align)
+			if _, err := uc.Write(blob); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+
+			// Nagłówek .h obok pliku .bin, z tą samą nazwą bazową
+			binName := uc.URI().Name()
+			hName := blobHeaderName(binName)
+			header := generateBlobHeader(f, opt, binName, layout, align, uint32(offset), blob, withCRC)
+			if err := writeSibling(uc.URI(), hName, header); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			dialog.ShowInformation(T("saved"), T("saved"), w)
+		}, w)
+		fd.SetFileName(strings.ToLower(f.BaseName()) + ".bin")
+		fd.Show()
+	}, w)
+}
+
+// blobHeaderName zwraca nazwę nagłówka dla pliku binarnego: ta sama nazwa bazowa
+// z rozszerzeniem .h, a jeśli sam plik binarny ma rozszerzenie .h - z końcówką "_bin",
+// żeby nagłówek nie nadpisał właśnie zapisanych danych
+func blobHeaderName(binName string) string {
+	base := strings.TrimSuffix(binName, filepath.Ext(binName))
+	if strings.EqualFold(base+".h", binName) {
+		return base + "_bin.h"
+	}
+	return base + ".h"
+}
+
+// writeSibling zapisuje plik tekstowy w tym samym katalogu co uri
+func writeSibling(uri fyne.URI, name, text string) error {
+	dir, err := storage.Parent(uri)
+	if err != nil {
+		return err
+	}
+	target, err := storage.Child(dir, name)
+	if err != nil {
+		return err
+	}
+	wc, err := storage.Writer(target)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = wc.Close() }()
+	_, err = wc.Write([]byte(text))
+	return err
+}
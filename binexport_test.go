@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBlobHeaderName(t *testing.T) {
+	tests := []struct{ bin, want string }{
+		{"font.bin", "font.h"},
+		{"font", "font.h"},
+		{"font.h", "font_bin.h"},
+		{"FONT.H", "FONT_bin.h"},
+		{"font.v2.bin", "font.v2.h"},
+	}
+	for _, tt := range tests {
+		if got := blobHeaderName(tt.bin); got != tt.want {
+			t.Errorf("blobHeaderName(%q) = %q, want %q", tt.bin, got, tt.want)
+		}
+	}
+}
+
+// Nagłówek bloku: typy z <stdint.h>, makro adresu znaku tylko dla ciągłych kodów
+func TestGenerateBlobHeader(t *testing.T) {
+	f := NewFont(5, 3, []uint16{0x11, 0x0A, 0x04, 0x1F, 0x00, 0x15, 0x01, 0x02, 0x03})
+	f.FirstChar = 'A'
+	tests := []struct {
+		name  string
+		opt   exportOptions
+		want  []string
+		avoid []string
+	}{
+		{"contiguous", exportOptions{},
+			[]string{"#include <stdint.h>", "_GLYPH_COUNT", "3u", "_FIRST_CHAR", "65u", "_GLYPH_ADDR(c)"},
+			[]string{"_CODES[]", "_GLYPH_ADDR_AT"}},
+		{"sparse subset", exportOptions{Glyphs: []int{0, 2}},
+			[]string{"#include <stdint.h>", "2u", "_CODES[] = { 65, 67 }", "_GLYPH_ADDR_AT(i)"},
+			[]string{"_GLYPH_ADDR(c)"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blob := buildBlob(f, tt.opt, layoutU16LE, 1)
+			if len(blob) != 2*f.Height*len(exportIndices(f, tt.opt)) {
+				t.Errorf("blob %d bytes", len(blob))
+			}
+			h := generateBlobHeader(f, tt.opt, "font.bin", layoutU16LE, 1, 0, blob, true)
+			for _, s := range tt.want {
+				if !strings.Contains(h, s) {
+					t.Errorf("header lacks %q:\n%s", s, h)
+				}
+			}
+			for _, s := range tt.avoid {
+				if strings.Contains(h, s) {
+					t.Errorf("header contains %q:\n%s", s, h)
+				}
+			}
+		})
+	}
+}
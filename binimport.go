@@ -0,0 +1,307 @@
+/* ============================================================================
+
+    Import binarny
+    Odzyskiwanie fontu ze zrzutu EEPROM / obrazu firmware (.bin lub Intel HEX)
+    – parseIntelHex, decodeBlob, importBinaryDialog
+
+    Użytkownik podaje offset startu, rozmiar glifu, liczbę znaków i układ,
+    a podgląd na żywo pokazuje glify od bieżącego offsetu - przesuwając
+    suwak łatwo znaleźć miejsce, w którym font zaczyna się w zrzucie.
+
+=========================================================================== */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Podgląd: liczba glifów w wierszu i skala
+const (
+	binPreviewCols  = 16
+	binPreviewRows  = 8
+	binPreviewScale = 2
+)
+
+// parseIntelHex dekoduje plik Intel HEX do ciągłego obrazu pamięci.
+// Obraz zaczyna się od najniższego adresu, luki są wypełniane 0xFF.
+func parseIntelHex(src string) ([]byte, error) {
+	mem := map[uint32]byte{}
+	var base uint32
+	minAddr, maxAddr := ^uint32(0), uint32(0)
+
+	for n, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if line[0] != ':' || len(line) < 11 || len(line)%2 == 0 {
+			return nil, fmt.Errorf("%s (%d)", T("badHexRecord"), n+1)
+		}
+		rec := make([]byte, (len(line)-1)/2)
+		var sum byte
+		for i := range rec {
+			v, err := strconv.ParseUint(line[1+2*i:3+2*i], 16, 8)
+			if err != nil {
+				return nil, fmt.Errorf("%s (%d)", T("badHexRecord"), n+1)
+			}
+			rec[i] = byte(v)
+			sum += byte(v)
+		}
+		count := int(rec[0])
+		if sum != 0 || len(rec) != count+5 {
+			return nil, fmt.Errorf("%s (%d)", T("badHexRecord"), n+1)
+		}
+		addr := uint32(rec[1])<<8 | uint32(rec[2])
+		data := rec[4 : 4+count]
+
+		switch rec[3] {
+		case 0x00: // dane
+			for i, b := range data {
+				a := base + addr + uint32(i)
+				mem[a] = b
+				minAddr = min(minAddr, a)
+				maxAddr = max(maxAddr, a)
+			}
+		case 0x01: // koniec pliku
+			return hexImage(mem, minAddr, maxAddr), nil
+		case 0x02: // rozszerzony adres segmentu
+			if count == 2 {
+				base = (uint32(data[0])<<8 | uint32(data[1])) << 4
+			}
+		case 0x04: // rozszerzony adres liniowy
+			if count == 2 {
+				base = (uint32(data[0])<<8 | uint32(data[1])) << 16
+			}
+		}
+	}
+	return hexImage(mem, minAddr, maxAddr), nil
+}
+
+// hexImage składa zebrane bajty w ciągły bufor
+func hexImage(mem map[uint32]byte, minAddr, maxAddr uint32) []byte {
+	if len(mem) == 0 {
+		return nil
+	}
+	img := make([]byte, maxAddr-minAddr+1)
+	for i := range img {
+		img[i] = 0xFF
+	}
+	for a, b := range mem {
+		img[a-minAddr] = b
+	}
+	return img
+}
+
+// blobCount zwraca liczbę glifów co stride bajtów, które zaczynają się w danych od offsetu
+func blobCount(data []byte, offset, stride int) int {
+	return max(0, (len(data)-offset+stride-1)/stride)
+}
+
+// decodeBlob odczytuje count glifów z danych od offsetu, w układzie jak przy eksporcie.
+// stride to odstęp między początkami kolejnych glifów w bajtach; count jest
+// ograniczany do glifów zaczynających się w danych.
+func decodeBlob(data []byte, offset, gw, gh, count, stride int, layout string) []uint16 {
+	count = min(count, blobCount(data, offset, stride))
+	rowBytes := 2
+	if layout == layoutHLSB {
+		rowBytes = (gw + 7) / 8
+	}
+	pad := rowBytes*8 - gw
+	mask := uint16(1<<gw - 1)
+
+	nums := make([]uint16, 0, count*gh)
+	for i := 0; i < count; i++ {
+		for y := 0; y < gh; y++ {
+			off := offset + i*stride + y*rowBytes
+			var row uint16
+			if off >= 0 && off+rowBytes <= len(data) {
+				switch layout {
+				case layoutU16LE:
+					row = uint16(data[off]) | uint16(data[off+1])<<8
+				case layoutU16BE:
+					row = uint16(data[off])<<8 | uint16(data[off+1])
+				default:
+					var v uint32
+					for b := 0; b < rowBytes; b++ {
+						v = v<<8 | uint32(data[off+b])
+					}
+					row = uint16(v >> pad)
+				}
+			}
+			nums = append(nums, row&mask)
+		}
+	}
+	return nums
+}
+
+// Wywoływane przy kliknięciu "Import .bin / .hex".
+// onLoaded otrzymuje zdekodowany font po zatwierdzeniu.
+func importBinaryDialog(w fyne.Window, onLoaded func(f *Font, name string)) {
+	dialog.ShowFileOpen(func(rc fyne.URIReadCloser, _ error) {
+		if rc == nil {
+			return
+		}
+		defer func() { _ = rc.Close() }()
+
+		raw, err := io.ReadAll(rc)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		ext := strings.ToLower(rc.URI().Extension())
+		if ext == ".hex" || ext == ".ihx" {
+			raw, err = parseIntelHex(string(raw))
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+		}
+		if len(raw) == 0 {
+			dialog.ShowError(errors.New(T("noData")), w)
+			return
+		}
+		openBinaryImportWindow(raw, rc.URI().Name(), onLoaded)
+	}, w)
+}
+
+// openBinaryImportWindow pokazuje okno z parametrami układu i podglądem na żywo
+func openBinaryImportWindow(data []byte, name string, onLoaded func(f *Font, name string)) {
+	win := fyne.CurrentApp().NewWindow(T("importBinary") + " – " + name)
+
+	offset, gw, gh, count, stride := 0, 8, 16, 96, 32
+	layout := layoutU16LE
+	var preview []uint16
+
+	// Raster podglądu: siatka binPreviewCols x binPreviewRows glifów od bieżącego offsetu
+	raster := canvas.NewRasterWithPixels(func(x, y, wR, hR int) color.Color {
+		cellW, cellH := (gw+1)*binPreviewScale, (gh+1)*binPreviewScale
+		col, row := x/cellW, y/cellH
+		gx, gy := (x%cellW)/binPreviewScale, (y%cellH)/binPreviewScale
+		i := row*binPreviewCols + col
+		if col >= binPreviewCols || gx >= gw || gy >= gh || i*gh+gy >= len(preview) {
+			return color.Gray{Y: 230}
+		}
+		if (preview[i*gh+gy]>>(gw-1-gx))&1 != 0 {
+			return color.Black
+		}
+		return color.White
+	})
+
+	infoLabel := widget.NewLabel("")
+	offsetSlider := widget.NewSlider(0, float64(len(data)-1))
+	offsetSlider.Step = 1
+	offsetEntry := widget.NewEntry()
+	widthEntry := widget.NewEntry()
+	heightEntry := widget.NewEntry()
+	countEntry := widget.NewEntry()
+	strideEntry := widget.NewEntry()
+	layoutSelect := widget.NewSelect(binLayouts, nil)
+
+	// Odczyt parametrów z pól i odświeżenie podglądu
+	update := func() {
+		atoi := func(e *widget.Entry, def int) int {
+			v, err := strconv.ParseInt(strings.TrimSpace(e.Text), 0, 32)
+			if err != nil || v < 0 {
+				return def
+			}
+			return int(v)
+		}
+		offset = min(atoi(offsetEntry, offset), len(data)-1)
+		gw = max(1, min(16, atoi(widthEntry, gw)))
+		gh = max(1, min(64, atoi(heightEntry, gh)))
+		stride = max(1, atoi(strideEntry, stride))
+		count = max(1, min(atoi(countEntry, count), blobCount(data, offset, stride)))
+		layout = layoutSelect.Selected
+
+		shown := min(count, binPreviewCols*binPreviewRows)
+		preview = decodeBlob(data, offset, gw, gh, shown, stride, layout)
+		raster.SetMinSize(fyne.NewSize(
+			float32(binPreviewCols*(gw+1)*binPreviewScale),
+			float32(binPreviewRows*(gh+1)*binPreviewScale)))
+		raster.Refresh()
+		infoLabel.SetText(fmt.Sprintf(T("binImportInfo"), len(data), offset, offset+count*stride))
+	}
+
+	// Domyślny stride wynika z rozmiaru glifu i układu
+	autoStride := func() {
+		rowBytes := 2
+		if layoutSelect.Selected == layoutHLSB {
+			rowBytes = (gw + 7) / 8
+		}
+		strideEntry.SetText(strconv.Itoa(rowBytes * gh))
+	}
+
+	offsetSlider.OnChanged = func(v float64) {
+		if int(v) != offset {
+			offsetEntry.SetText(strconv.Itoa(int(v)))
+		}
+	}
+	offsetEntry.OnChanged = func(string) {
+		update()
+		if float64(offset) != offsetSlider.Value {
+			offsetSlider.SetValue(float64(offset))
+		}
+	}
+	widthEntry.OnChanged = func(string) { update(); autoStride() }
+	heightEntry.OnChanged = func(string) { update(); autoStride() }
+	layoutSelect.OnChanged = func(string) { update(); autoStride() }
+	countEntry.OnChanged = func(string) { update() }
+	strideEntry.OnChanged = func(string) { update() }
+
+	layoutSelect.SetSelected(layout)
+	offsetEntry.SetText("0")
+	widthEntry.SetText(strconv.Itoa(gw))
+	heightEntry.SetText(strconv.Itoa(gh))
+	countEntry.SetText(strconv.Itoa(count))
+
+	// Przyciski przesunięcia o bajt / o glif
+	step := func(d int) func() {
+		return func() {
+			offsetEntry.SetText(strconv.Itoa(max(0, min(len(data)-1, offset+d))))
+		}
+	}
+	stepBtns := container.NewHBox(
+		widget.NewButton("−"+T("glyph"), func() { step(-stride)() }),
+		widget.NewButton("−1", step(-1)),
+		widget.NewButton("+1", step(1)),
+		widget.NewButton("+"+T("glyph"), func() { step(stride)() }),
+	)
+
+	importBtn := widget.NewButton(T("importAction"), func() {
+		update()
+		nums := decodeBlob(data, offset, gw, gh, count, stride, layout)
+		onLoaded(NewFont(gw, gh, nums), name)
+		win.Close()
+	})
+
+	form := widget.NewForm(
+		widget.NewFormItem(T("binOffset"), offsetEntry),
+		widget.NewFormItem(T("glyphWidth"), widthEntry),
+		widget.NewFormItem(T("glyphHeight"), heightEntry),
+		widget.NewFormItem(T("glyphCount"), countEntry),
+		widget.NewFormItem(T("binStride"), strideEntry),
+		widget.NewFormItem(T("binLayout"), layoutSelect),
+	)
+
+	win.SetContent(container.NewBorder(
+		container.NewVBox(form, offsetSlider, stepBtns, infoLabel),
+		container.NewHBox(importBtn, widget.NewButton(T("close"), func() { win.Close() })),
+		nil,
+		nil,
+		container.NewScroll(container.NewCenter(raster)),
+	))
+	win.Resize(fyne.NewSize(640, 720))
+	win.Show()
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// hexRecord składa rekord Intel HEX z sumą kontrolną
+func hexRecord(addr uint16, typ byte, data ...byte) string {
+	rec := append([]byte{byte(len(data)), byte(addr >> 8), byte(addr), typ}, data...)
+	var sum byte
+	for _, b := range rec {
+		sum += b
+	}
+	rec = append(rec, -sum)
+	return ":" + strings.ToUpper(fmt.Sprintf("%x", rec))
+}
+
+func TestParseIntelHex(t *testing.T) {
+	eof := hexRecord(0, 0x01)
+	tests := []struct {
+		name    string
+		records []string
+		want    []byte
+	}{
+		{"data", []string{hexRecord(0x0010, 0x00, 1, 2, 3), eof}, []byte{1, 2, 3}},
+		{"gap filled with 0xFF", []string{hexRecord(0, 0x00, 1), hexRecord(3, 0x00, 4), eof}, []byte{1, 0xFF, 0xFF, 4}},
+		{"extended segment address", []string{
+			hexRecord(0, 0x00, 1),
+			hexRecord(0, 0x02, 0x00, 0x01), // baza 0x10
+			hexRecord(0, 0x00, 2),
+			eof,
+		}, append(append([]byte{1}, bytes.Repeat([]byte{0xFF}, 15)...), 2)},
+		{"extended linear address", []string{
+			hexRecord(0, 0x04, 0x00, 0x01), // baza 0x10000
+			hexRecord(0x0002, 0x00, 7, 8),
+			eof,
+		}, []byte{7, 8}},
+		{"start address records ignored", []string{
+			hexRecord(0, 0x03, 0, 0, 0, 0),
+			hexRecord(0, 0x05, 0, 0, 0, 0),
+			hexRecord(0, 0x00, 9),
+			eof,
+		}, []byte{9}},
+		{"data after end of file ignored", []string{hexRecord(0, 0x00, 1), eof, hexRecord(1, 0x00, 2)}, []byte{1}},
+		{"no end of file record", []string{hexRecord(0, 0x00, 5, 6)}, []byte{5, 6}},
+		{"empty", []string{eof}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseIntelHex(strings.Join(tt.records, "\r\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("got % X, want % X", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIntelHexErrors(t *testing.T) {
+	good := hexRecord(0, 0x00, 1, 2)
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"missing colon", good[1:]},
+		{"bad checksum", good[:len(good)-2] + "00"},
+		{"length mismatch", ":030000000102FA"},
+		{"not hex", ":0100000GZZ00"},
+		{"too short", ":00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseIntelHex(tt.src); err == nil {
+				t.Errorf("parseIntelHex(%q): expected error", tt.src)
+			}
+		})
+	}
+}
+
+func TestDecodeBlob(t *testing.T) {
+	rows := []uint16{0x0081, 0x00FF, 0x0042}
+	tests := []struct {
+		layout string
+		gw     int
+		data   []byte
+	}{
+		{layoutU16LE, 8, []byte{0x81, 0x00, 0xFF, 0x00, 0x42, 0x00}},
+		{layoutU16BE, 8, []byte{0x00, 0x81, 0x00, 0xFF, 0x00, 0x42}},
+		{layoutHLSB, 8, []byte{0x81, 0xFF, 0x42}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			got := decodeBlob(tt.data, 0, tt.gw, 1, 3, len(tt.data)/3, tt.layout)
+			if !slices.Equal(got, rows) {
+				t.Errorf("got %04X, want %04X", got, rows)
+			}
+		})
+	}
+}
+
+// Eksport .bin i odczyt z powrotem dają te same wiersze (także z wyrównaniem i offsetem)
+func TestDecodeBlobRoundTrip(t *testing.T) {
+	f := NewFont(5, 3, []uint16{0x11, 0x0A, 0x04, 0x1F, 0x00, 0x15})
+	for _, layout := range binLayouts {
+		for _, align := range []int{1, 4, 16} {
+			blob := buildBlob(f, exportOptions{}, layout, align)
+			data := append([]byte{0xEE, 0xEE}, blob...)
+			got := decodeBlob(data, 2, f.Width, f.Height, f.Count(), binStride(f, layout, align), layout)
+			if !slices.Equal(got, f.Data) {
+				t.Errorf("%s align %d: got %02X, want %02X", layout, align, got, f.Data)
+			}
+		}
+	}
+}
+
+// Liczba glifów ograniczona do danych - ogromna wartość z pola nie alokuje pamięci na zapas
+func TestDecodeBlobCountClamp(t *testing.T) {
+	data := []byte{0x81, 0xFF, 0x42, 0x18, 0x24}
+	tests := []struct {
+		offset, count, stride int
+		want                  int // liczba wierszy
+	}{
+		{0, 2000000000, 2, 3},
+		{1, 2000000000, 2, 2},
+		{4, 10, 1, 1},
+		{0, 2, 1, 2},
+		{0, 1, 1 << 30, 1},
+	}
+	for _, tt := range tests {
+		got := decodeBlob(data, tt.offset, 8, 1, tt.count, tt.stride, layoutHLSB)
+		if len(got) != tt.want {
+			t.Errorf("offset %d count %d stride %d: %d rows, want %d", tt.offset, tt.count, tt.stride, len(got), tt.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+/* ============================================================================
+
+    Zaznaczenie i schowek glifów
+    Narzędzie zaznaczania w oknie edycji (przesuwanie fragmentu glifu),
+    kopiowanie i wklejanie fragmentów oraz całych glifów – także między
+    glifami i między fontami otwartymi w różnych zakładkach
+    – copyRegion, pasteRegion, pasteGlyph, glyphEditor.setSelection /
+      previewMove / moveSelection / copySelection / pasteClipboard
+
+    Schowek to jednoglifowy Font z wyciętym prostokątem, wspólny dla
+    wszystkich zakładek. Przy wklejaniu do fontu o innej głębi piksele
+    przeliczane są przez jasność (Font.Intensity / Font.LevelOf).
+    Fragment wklejany jest z przezroczystym tłem (puste piksele nie
+    zamazują glifu), cały glif z głównego okna zastępuje glif docelowy.
+
+=========================================================================== */
+
+package main
+
+import (
+	"image"
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+var glyphClipboard *Font // skopiowany fragment lub cały glif (nil = pusty schowek)
+
+// copyRegion zwraca jednoglifowy font z prostokąta r glifu g
+// (cały glif: także szerokość i odstęp z lewej fontu proporcjonalnego)
+func copyRegion(g Glyph, r image.Rectangle) *Font {
+	src := g.Font
+	c := &Font{Width: r.Dx(), Height: r.Dy(), FirstChar: g.Code(), Depth: src.Depth, Data: make([]uint16, r.Dy())}
+	if src.Pixels != nil {
+		c.Pixels = make([]uint16, r.Dx()*r.Dy())
+	}
+	dst := c.Glyph(0)
+	for y := 0; y < r.Dy(); y++ {
+		for x := 0; x < r.Dx(); x++ {
+			dst.SetLevel(x, y, g.Level(r.Min.X+x, r.Min.Y+y))
+		}
+	}
+	if src.Proportional() && r == glyphBounds(src) {
+		dst.SetMetrics(g.Bearing(), g.Advance())
+	}
+	return c
+}
+
+// glyphBounds zwraca prostokąt całej komórki glifu
+func glyphBounds(f *Font) image.Rectangle {
+	return image.Rect(0, 0, f.Width, f.Height)
+}
+
+// clipLevel zwraca piksel schowka przeliczony do głębi fontu f
+func clipLevel(clip *Font, f *Font, x, y int) int {
+	level := clip.Glyph(0).Level(x, y)
+	if level == 0 || clip.Depth == f.Depth {
+		return level
+	}
+	return f.LevelOf(clip.Intensity(level))
+}
+
+// pasteRegion wkleja schowek do glifu g z lewym górnym rogiem w at
+// (puste piksele schowka są przezroczyste); zwraca false, jeśli glif się nie zmienił
+func pasteRegion(clip *Font, g Glyph, at image.Point) bool {
+	bounds := glyphBounds(g.Font)
+	changed := false
+	for y := 0; y < clip.Height; y++ {
+		for x := 0; x < clip.Width; x++ {
+			p := at.Add(image.Pt(x, y))
+			level := clipLevel(clip, g.Font, x, y)
+			if level == 0 || !p.In(bounds) || g.Level(p.X, p.Y) == level {
+				continue
+			}
+			g.SetLevel(p.X, p.Y, level)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// pasteGlyph zastępuje glif g zawartością schowka (od lewego górnego rogu)
+func pasteGlyph(clip *Font, g Glyph) {
+	g.SetLevels(make([]uint16, g.Font.Width*g.Font.Height))
+	pasteRegion(clip, g, image.Point{})
+	if g.Font.Proportional() {
+		if clip.Proportional() {
+			g.SetMetrics(clip.Bearing[0], clip.Advance[0])
+		} else {
+			g.AutoWidth()
+		}
+	}
+}
+
+// clearRegion gasi piksele prostokąta r glifu g
+func clearRegion(g Glyph, r image.Rectangle) {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			g.SetLevel(x, y, 0)
+		}
+	}
+}
+
+// newSelectionRect tworzy ramkę zaznaczenia rysowaną nad siatką okna edycji
+func newSelectionRect() *canvas.Rectangle {
+	r := canvas.NewRectangle(color.NRGBA{B: 255, A: 40})
+	r.StrokeColor = color.NRGBA{B: 220, A: 220}
+	r.StrokeWidth = 2
+	r.Hide()
+	return r
+}
+
+// setSelection ustawia zaznaczenie (przycięte do komórki; pusty prostokąt usuwa zaznaczenie)
+func (ed *glyphEditor) setSelection(r image.Rectangle) {
+	ed.sel = r.Canon().Intersect(glyphBounds(ed.font))
+	ed.showSelection(ed.sel)
+}
+
+// showSelection ustawia ramkę zaznaczenia na prostokącie r (także podczas przesuwania)
+func (ed *glyphEditor) showSelection(r image.Rectangle) {
+	if r.Empty() {
+		ed.selRect.Hide()
+		return
+	}
+	ed.selRect.Move(fyne.NewPos(float32(r.Min.X)*ed.pixelSize, float32(r.Min.Y)*ed.pixelSize))
+	ed.selRect.Resize(fyne.NewSize(float32(r.Dx())*ed.pixelSize, float32(r.Dy())*ed.pixelSize))
+	ed.selRect.Show()
+	ed.selRect.Refresh()
+}
+
+// selectionSpan zwraca prostokąt pikseli od (x0, y0) do (x1, y1) włącznie
+func selectionSpan(x0, y0, x1, y1 int) image.Rectangle {
+	return image.Rect(min(x0, x1), min(y0, y1), max(x0, x1)+1, max(y0, y1)+1)
+}
+
+// previewMove pokazuje zaznaczony fragment przesunięty o d, nie zmieniając glifu
+func (ed *glyphEditor) previewMove(lifted *Font, d image.Point) {
+	g := copyRegion(ed.glyph(), glyphBounds(ed.font)).Glyph(0)
+	clearRegion(g, ed.sel)
+	pasteRegion(lifted, g, ed.sel.Min.Add(d))
+	ed.showLevels(g.Levels())
+	ed.showSelection(ed.sel.Add(d))
+}
+
+// moveSelection przesuwa zaznaczony fragment o d jako jeden wpis UNDO
+func (ed *glyphEditor) moveSelection(lifted *Font, d image.Point) {
+	if d == (image.Point{}) {
+		ed.refreshGrid()
+		return
+	}
+	g := ed.glyph()
+	ed.history.Push(g, ed.xShift, ed.yShift)
+	clearRegion(g, ed.sel)
+	pasteRegion(lifted, g, ed.sel.Min.Add(d))
+	ed.setSelection(ed.sel.Add(d))
+	ed.refreshGrid()
+}
+
+// copySelection kopiuje zaznaczenie (bez zaznaczenia - cały glif) do schowka
+func (ed *glyphEditor) copySelection() {
+	r := ed.sel
+	if r.Empty() {
+		r = glyphBounds(ed.font)
+	}
+	glyphClipboard = copyRegion(ed.glyph(), r)
+}
+
+// pasteClipboard wkleja schowek w lewym górnym rogu zaznaczenia (lub komórki)
+// jako jeden wpis UNDO; wklejony fragment staje się zaznaczeniem
+func (ed *glyphEditor) pasteClipboard() {
+	clip := glyphClipboard
+	if clip == nil {
+		return
+	}
+	g := ed.glyph()
+	at := ed.sel.Min
+	ed.history.Push(g, ed.xShift, ed.yShift)
+	pasteRegion(clip, g, at)
+	ed.setSelection(image.Rectangle{Min: at, Max: at.Add(image.Pt(clip.Width, clip.Height))})
+	ed.refreshGrid()
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"image"
+	"slices"
+	"testing"
+)
+
+func TestCopyRegion(t *testing.T) {
+	f := artFont("#..#", ".##.", "#..#")
+	c := copyRegion(f.Glyph(0), image.Rect(1, 0, 4, 2))
+	if got := glyphArt(c.Glyph(0)); !slices.Equal(got, []string{"..#", "##."}) {
+		t.Errorf("1bpp region %q", got)
+	}
+
+	gray := NewGrayFont(3, 2, 4, levelsFrom("123", "456"))
+	c = copyRegion(gray.Glyph(0), image.Rect(1, 1, 3, 2))
+	if c.Depth != 4 || !slices.Equal(c.Glyph(0).Levels(), levelsFrom("56")) {
+		t.Errorf("4bpp region depth %d levels %v", c.Depth, c.Glyph(0).Levels())
+	}
+
+	// cały glif fontu proporcjonalnego niesie szerokość, fragment - nie
+	f.Glyph(0).SetMetrics(1, 2)
+	if c := copyRegion(f.Glyph(0), glyphBounds(f)); !c.Proportional() || c.Glyph(0).Bearing() != 1 || c.Glyph(0).Advance() != 2 {
+		t.Error("whole glyph lost its metrics")
+	}
+	if copyRegion(f.Glyph(0), image.Rect(0, 0, 2, 2)).Proportional() {
+		t.Error("region copied glyph metrics")
+	}
+}
+
+// Puste piksele schowka są przezroczyste, fragment wystający poza komórkę jest przycinany
+func TestPasteRegion(t *testing.T) {
+	clip := artFont("#.", ".#")
+	tests := []struct {
+		name    string
+		at      image.Point
+		glyph   []string
+		want    []string
+		changed bool
+	}{
+		{"transparent", image.Pt(0, 0), []string{".#.", "#..", "..."}, []string{"##.", "##.", "..."}, true},
+		{"clipped", image.Pt(2, 2), []string{"...", "...", "..."}, []string{"...", "...", "..#"}, true},
+		{"negative", image.Pt(-1, -1), []string{"...", "...", "..."}, []string{"#..", "...", "..."}, true},
+		{"already there", image.Pt(1, 0), []string{".#.", "..#", "..."}, []string{".#.", "..#", "..."}, false},
+		{"outside", image.Pt(3, 0), []string{"...", "...", "..."}, []string{"...", "...", "..."}, false},
+	}
+	for _, tt := range tests {
+		f := artFont(tt.glyph...)
+		changed := pasteRegion(clip, f.Glyph(0), tt.at)
+		if got := glyphArt(f.Glyph(0)); !slices.Equal(got, tt.want) || changed != tt.changed {
+			t.Errorf("%s: got %q (changed %t), want %q (changed %t)", tt.name, got, changed, tt.want, tt.changed)
+		}
+	}
+}
+
+// Wklejanie do fontu o innej głębi przelicza piksele przez jasność;
+// piksel, który w nowej głębi wypada jako tło, niczego nie zamazuje
+func TestPasteRegionDepth(t *testing.T) {
+	tests := []struct {
+		name string
+		clip *Font
+		dst  *Font
+		want []uint16
+	}{
+		{"1bpp into 4bpp", artFont("#.#"), NewGrayFont(3, 1, 4, levelsFrom("050")), levelsFrom("?5?")},
+		{"4bpp into 1bpp", NewGrayFont(3, 1, 4, levelsFrom("79?")), NewFont(3, 1, []uint16{0b100}), []uint16{1, 1, 1}},
+		{"4bpp faint into 1bpp", NewGrayFont(2, 1, 4, levelsFrom("30")), NewFont(2, 1, []uint16{0}), []uint16{0, 0}},
+		{"2bpp into 4bpp", NewGrayFont(3, 1, 2, levelsFrom("123")), NewGrayFont(3, 1, 4, levelsFrom("000")), levelsFrom("5:?")},
+		{"1bpp into RGB565", artFont("#."), NewIconFont(2, 1, []uint16{0, 0xF800}, false), []uint16{0xFFFF, 0xF800}},
+		{"RGB565 into 2bpp", NewIconFont(2, 1, []uint16{0xFFFF, 0x07E0}, false), NewGrayFont(2, 1, 2, levelsFrom("00")), levelsFrom("32")},
+	}
+	for _, tt := range tests {
+		pasteRegion(tt.clip, tt.dst.Glyph(0), image.Point{})
+		if got := tt.dst.Glyph(0).Levels(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Cały glif zastępuje glif docelowy; w foncie proporcjonalnym niesie szerokość
+// lub (schowek bez szerokości) szerokość liczona jest z tuszu
+func TestPasteGlyph(t *testing.T) {
+	f := artFont("####", "####")
+	f.SetProportional(true)
+	clip := artFont(".#", "..")
+	pasteGlyph(clip, f.Glyph(0))
+	if got := glyphArt(f.Glyph(0)); !slices.Equal(got, []string{".#..", "...."}) {
+		t.Errorf("got %q", got)
+	}
+	if g := f.Glyph(0); g.Bearing() != 1 || g.Advance() != 1+autoSpacing {
+		t.Errorf("auto metrics %d/%d", g.Bearing(), g.Advance())
+	}
+
+	clip.Glyph(0).SetMetrics(0, 2)
+	pasteGlyph(clip, f.Glyph(0))
+	if g := f.Glyph(0); g.Bearing() != 0 || g.Advance() != 2 {
+		t.Errorf("clipboard metrics %d/%d", g.Bearing(), g.Advance())
+	}
+}
+
+// Przeciągnięcie zaznaczenia przesuwa fragment jednym wpisem UNDO, ramka idzie za nim
+func TestMoveSelection(t *testing.T) {
+	f := artFont("##..", "##..", "....")
+	ed, p := testEditor(t, f)
+	drawTool = toolSelect
+
+	stroke(ed, p, [2]int{0, 0}, [2]int{1, 1})
+	if ed.sel != image.Rect(0, 0, 2, 2) {
+		t.Fatalf("selection %v", ed.sel)
+	}
+	stroke(ed, p, [2]int{1, 1}, [2]int{2, 1}, [2]int{3, 2})
+	if got := glyphArt(f.Glyph(0)); !slices.Equal(got, []string{"....", "..##", "..##"}) {
+		t.Errorf("got %q", got)
+	}
+	if ed.sel != image.Rect(2, 1, 4, 3) || len(ed.history.undoStack) != 1 {
+		t.Errorf("selection %v, %d undo entries", ed.sel, len(ed.history.undoStack))
+	}
+
+	// kliknięcie poza zaznaczeniem bez przeciągania usuwa zaznaczenie
+	stroke(ed, p, [2]int{0, 0})
+	if !ed.sel.Empty() {
+		t.Errorf("selection %v after click", ed.sel)
+	}
+}
+
+// Kopiuj / wklej w oknie edycji: wklejony fragment w rogu zaznaczenia, jeden wpis UNDO
+func TestCopyPasteSelection(t *testing.T) {
+	saved := glyphClipboard
+	t.Cleanup(func() { glyphClipboard = saved })
+
+	f := artFont("#...", ".#..", "....")
+	ed, _ := testEditor(t, f)
+	ed.setSelection(image.Rect(0, 0, 2, 2))
+	ed.copySelection()
+	ed.setSelection(image.Rect(2, 1, 3, 2))
+	ed.pasteClipboard()
+	if got := glyphArt(f.Glyph(0)); !slices.Equal(got, []string{"#...", ".##.", "...#"}) {
+		t.Errorf("got %q", got)
+	}
+	if ed.sel != image.Rect(2, 1, 4, 3) || len(ed.history.undoStack) != 1 {
+		t.Errorf("selection %v, %d undo entries", ed.sel, len(ed.history.undoStack))
+	}
+	ed.history.Undo(f, 0, 0)
+	if got := glyphArt(f.Glyph(0)); !slices.Equal(got, []string{"#...", ".#..", "...."}) {
+		t.Errorf("after undo %q", got)
+	}
+}
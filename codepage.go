@@ -0,0 +1,143 @@
+/* ============================================================================
+
+    Strony kodowe
+    Fonty dla wyświetlaczy bez Unicode mają glify ułożone według strony
+    kodowej (CP1250, ISO-8859-2, CP437, CP1252) – indeksy powyżej 127
+    to np. polskie litery ą, ę, ł
+    – Font.Rune, Font.IndexOfRune, Font.ConvertCodepage, parseCodepage
+
+    Font.Codepage = "" oznacza kody znaków w Unicode. Ze stroną kodową
+    kody glifów to bajty 0..255, a Font.Rune zwraca odpowiadający im
+    znak Unicode (etykiety, komentarze w eksporcie, podgląd napisu).
+
+=========================================================================== */
+
+package main
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Obsługiwane strony kodowe
+var codepages = map[string]*charmap.Charmap{
+	"CP1250":     charmap.Windows1250,
+	"ISO-8859-2": charmap.ISO8859_2,
+	"CP437":      charmap.CodePage437,
+	"CP1252":     charmap.Windows1252,
+}
+
+// Kolejność stron kodowych na liście wyboru
+var codepageNames = []string{"CP1250", "ISO-8859-2", "CP437", "CP1252"}
+
+// Znacznik strony kodowej w eksporcie: "// codepage: CP1250", "# codepage: CP1250"
+var codepageRE = regexp.MustCompile(`(?i)\bcodepage:\s*([\w-]+)`)
+
+// codepageRune zwraca znak Unicode dla kodu w stronie kodowej ("" = Unicode)
+func codepageRune(cp string, code int) rune {
+	if cm := codepages[cp]; cm != nil && code >= 0 && code <= 0xFF {
+		return cm.DecodeByte(byte(code))
+	}
+	return rune(code)
+}
+
+// codepageCode zwraca kod znaku Unicode w stronie kodowej (-1, jeśli go nie ma)
+func codepageCode(cp string, r rune) int {
+	cm := codepages[cp]
+	if cm == nil {
+		return int(r)
+	}
+	if b, ok := cm.EncodeRune(r); ok {
+		return int(b)
+	}
+	return -1
+}
+
+// Rune zwraca znak Unicode glifu o podanym kodzie
+func (f *Font) Rune(code int) rune {
+	return codepageRune(f.Codepage, code)
+}
+
+// IndexOfRune zwraca indeks glifu znaku Unicode (z uwzględnieniem strony kodowej) lub -1
+func (f *Font) IndexOfRune(r rune) int {
+	code := codepageCode(f.Codepage, r)
+	if code < 0 {
+		return -1
+	}
+	return f.Index(code)
+}
+
+// ConvertCodepage przestawia glify do układu innej strony kodowej: każdy kod
+// z dotychczasowego zakresu dostaje glif tego samego znaku Unicode (lub pusty).
+// Zwraca liczbę glifów z tuszem, dla których zabrakło miejsca w nowej stronie.
+func (f *Font) ConvertCodepage(target string) int {
+	if f.Count() == 0 {
+		// font bez glifów (np. po usunięciu wszystkich) - zmienia się tylko strona kodowa
+		f.Codepage = target
+		return 0
+	}
+	old := f.Clone()
+	first, last := old.Code(0), old.Code(0)
+	for i := range old.Count() {
+		first, last = min(first, old.Code(i)), max(last, old.Code(i))
+	}
+
+	f.Data, f.Codes, f.FirstChar, f.Codepage = nil, nil, first, target
+	if old.Pixels != nil {
+		f.Pixels = []uint16{}
+	}
+	if old.Proportional() {
+		f.Advance, f.Bearing = []int{}, []int{}
+	}
+
+	placed := make([]bool, old.Count())
+	for code := first; code <= last; code++ {
+		at := f.Count()
+		src := old.IndexOfRune(codepageRune(target, code))
+		if src < 0 {
+			f.insertGlyph(at, code, make([]uint16, f.Height), nil)
+			if f.Proportional() {
+				f.Glyph(at).AutoWidth()
+			}
+			continue
+		}
+		g := old.Glyph(src)
+		f.insertGlyph(at, code, slices.Clone(g.Rows()), levelsOf(g))
+		if f.Proportional() {
+			f.Glyph(at).SetMetrics(g.Bearing(), g.Advance())
+		}
+		placed[src] = true
+	}
+
+	lost := 0
+	for i, g := range old.Glyphs() {
+		if _, _, ink := g.InkBounds(); ink && !placed[i] {
+			lost++
+		}
+	}
+	return lost
+}
+
+// parseCodepage odczytuje stronę kodową zapisaną przy eksporcie
+func parseCodepage(f *Font, src string) {
+	if m := codepageRE.FindStringSubmatch(src); m != nil {
+		if name := strings.ToUpper(m[1]); codepages[name] != nil {
+			f.Codepage = name
+		}
+	}
+}
+
+// withCodepageNote dopisuje znacznik strony kodowej na końcu nagłówka wygenerowanego pliku
+func withCodepageNote(f *Font, src, comment string) string {
+	if f.Codepage == "" {
+		return src
+	}
+	end := strings.Index(src, "\n\n")
+	if end < 0 {
+		return src
+	}
+	return src[:end+1] + comment + " codepage: " + f.Codepage + "\n" + src[end+1:]
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestConvertCodepage(t *testing.T) {
+	// CP1250: 0xB9 = 'ą', 0xA5 = 'Ą', 0x80 = '€'; ISO-8859-2: 0xB1 = 'ą', 0xA1 = 'Ą', brak '€'
+	f := NewFont(3, 1, []uint16{1, 2, 3, 4})
+	f.Codes = []int{'A', 0x80, 0xA5, 0xB9}
+	f.Codepage = "CP1250"
+
+	lost := f.ConvertCodepage("ISO-8859-2")
+	if lost != 1 {
+		t.Errorf("lost %d glyphs, want 1 ('€')", lost)
+	}
+	if f.Codepage != "ISO-8859-2" || f.Codes != nil || f.FirstChar != 'A' || f.Count() != 0xB9-'A'+1 {
+		t.Fatalf("codepage %q, first %#x, %d glyphs", f.Codepage, f.FirstChar, f.Count())
+	}
+	for _, tt := range []struct {
+		r   rune
+		row uint16
+	}{{'A', 1}, {'Ą', 3}, {'ą', 4}} {
+		i := f.IndexOfRune(tt.r)
+		if i < 0 || f.Glyph(i).Row(0) != tt.row {
+			t.Errorf("%q: glyph %d, want row %d", tt.r, i, tt.row)
+		}
+	}
+	if i := f.Index(0xB9); f.Rune(0xB9) != 'š' || f.Glyph(i).Row(0) != 0 {
+		t.Errorf("0xB9 is %q with row %d, want empty 'š'", f.Rune(0xB9), f.Glyph(i).Row(0))
+	}
+}
+
+// Glify proporcjonalne i w odcieniach szarości przenoszą poziomy i szerokości
+func TestConvertCodepageGrayProportional(t *testing.T) {
+	f := NewGrayFont(2, 1, 2, []uint16{3, 0, 1, 2})
+	f.Codes = []int{0xA1, 0xB3} // ISO-8859-2: 'Ą', 'ł' (CP1250: 0xA5, 0xB3)
+	f.Codepage = "ISO-8859-2"
+	f.Glyph(0).SetMetrics(0, 1)
+	f.Glyph(1).SetMetrics(0, 2)
+
+	if lost := f.ConvertCodepage("CP1250"); lost != 0 {
+		t.Fatalf("lost %d glyphs", lost)
+	}
+	for _, tt := range []struct {
+		r       rune
+		levels  []uint16
+		advance int
+	}{{'Ą', []uint16{3, 0}, 1}, {'ł', []uint16{1, 2}, 2}} {
+		g := f.Glyph(f.IndexOfRune(tt.r))
+		if !slices.Equal(g.Levels(), tt.levels) || g.Advance() != tt.advance {
+			t.Errorf("%q: levels %v advance %d", tt.r, g.Levels(), g.Advance())
+		}
+	}
+}
+
+// Font bez glifów (po usunięciu wszystkich) zmienia tylko stronę kodową
+func TestConvertCodepageEmpty(t *testing.T) {
+	for _, codes := range [][]int{nil, {}} {
+		f := NewFont(3, 2, nil)
+		f.Codes = codes
+		if lost := f.ConvertCodepage("CP437"); lost != 0 || f.Codepage != "CP437" || f.Count() != 0 {
+			t.Errorf("codes %v: lost %d, codepage %q, %d glyphs", codes, lost, f.Codepage, f.Count())
+		}
+	}
+}
+
+func TestCodepageRune(t *testing.T) {
+	tests := []struct {
+		cp   string
+		code int
+		r    rune
+	}{
+		{"CP1250", 0xB9, 'ą'},
+		{"ISO-8859-2", 0xB1, 'ą'},
+		{"CP437", 0xDB, '█'},
+		{"CP1252", 0x80, '€'},
+		{"", 0x104, 'Ą'},
+		{"CP1250", 'A', 'A'},
+	}
+	for _, tt := range tests {
+		if r := codepageRune(tt.cp, tt.code); r != tt.r {
+			t.Errorf("codepageRune(%q, 0x%X) = %q, want %q", tt.cp, tt.code, r, tt.r)
+		}
+		if code := codepageCode(tt.cp, tt.r); code != tt.code {
+			t.Errorf("codepageCode(%q, %q) = 0x%X, want 0x%X", tt.cp, tt.r, code, tt.code)
+		}
+	}
+	if codepageCode("ISO-8859-2", '€') != -1 {
+		t.Error("'€' found in ISO-8859-2")
+	}
+}
+
+// Strona kodowa wraca z pliku w każdym formacie (znacznik w nagłówku)
+func TestCodepageRoundTrip(t *testing.T) {
+	f := testFont(t, 8, 8, 1)
+	f.Codepage = "CP1250"
+	roundTrip(t, f, exportOptions{})
+	if src := generateFontSource(f, formatPython, exportOptions{}); !strings.Contains(src, "# codepage: CP1250\n") {
+		t.Errorf("no codepage note:\n%s", src)
+	}
+}
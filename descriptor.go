@@ -0,0 +1,258 @@
+/* ============================================================================
+
+    Deskryptor fontu
+    Struktura opisująca font (wymiary, zakres znaków, metryki, wskaźnik
+    na dane, tabele szerokości fontu proporcjonalnego)
+    dopisywana za tablicą przy eksporcie, żeby firmware nie musiało
+    na sztywno wpisywać szerokości, wysokości i pierwszego znaku
+    – generateDescriptorC, generateDescriptorRust, generateDescriptorGo
+
+    Moduł MicroPython nie dostaje struktury – jego stałe (WIDTH, HEIGHT,
+    FIRST_CHAR, COUNT, BASELINE...) pełnią tę rolę.
+
+=========================================================================== */
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// descriptorName zwraca poprawny identyfikator typu struktury
+func descriptorName(opt exportOptions) string {
+	name := strings.TrimSpace(opt.DescriptorType)
+	if name == "" || !isIdentByte(name[0]) || (name[0] >= '0' && name[0] <= '9') {
+		return "FontDef"
+	}
+	for i := 0; i < len(name); i++ {
+		if !isIdentByte(name[i]) {
+			return "FontDef"
+		}
+	}
+	return name
+}
+
+// descriptorTypeFor zwraca nazwę typu deskryptora; fonty w odcieniach szarości
+// i ikony RGB565 mają osobne typy (pole bpp), żeby nie kolidować z fontami 1bpp
+func descriptorTypeFor(f *Font, opt exportOptions) string {
+	if f.RGB565() {
+		return descriptorName(opt) + "Icon"
+	}
+	if f.Depth > 1 {
+		return descriptorName(opt) + "Gray"
+	}
+	return descriptorName(opt)
+}
+
+// fontDescriptorType zwraca typ deskryptora poprzedzony nazwą fontu (Rust, Go);
+// C ma strażnika #ifndef, tu kilka eksportów w jednym module/pakiecie
+// deklarowałoby ten sam typ wielokrotnie
+func fontDescriptorType(f *Font, opt exportOptions) string {
+	return goFontName(f) + descriptorTypeFor(f, opt)
+}
+
+// lastChar zwraca kod ostatniego zapisywanego znaku
+func lastChar(f *Font, opt exportOptions) int {
+	codes := exportCodes(f, opt)
+	if len(codes) == 0 {
+		return f.FirstChar
+	}
+	return codes[len(codes)-1]
+}
+
+// generateDescriptorC generuje typedef struktury i jej instancję dla tablicy C
+func generateDescriptorC(f *Font, opt exportOptions) string {
+	var sb strings.Builder
+	typ := descriptorTypeFor(f, opt)
+	guard := strings.ToUpper(typ) + "_DEFINED"
+
+	sb.WriteString("\n#ifndef " + guard + "\n")
+	sb.WriteString("#define " + guard + "\n")
+	sb.WriteString("typedef struct {\n")
+	sb.WriteString("    uint8_t  width;\n")
+	sb.WriteString("    uint8_t  height;\n")
+	if f.Depth > 1 {
+		sb.WriteString("    uint8_t  bpp;\n")
+	}
+	sb.WriteString("    uint16_t bytesPerGlyph;\n")
+	sb.WriteString("    uint16_t firstChar;\n")
+	sb.WriteString("    uint16_t lastChar;\n")
+	sb.WriteString("    uint16_t glyphCount;\n")
+	sb.WriteString("    uint8_t  baseline;\n")
+	sb.WriteString("    uint8_t  ascent;\n")
+	sb.WriteString("    uint8_t  descent;\n")
+	sb.WriteString("    uint8_t  xHeight;\n")
+	sb.WriteString("    uint8_t  capHeight;\n")
+	sb.WriteString("    uint8_t  lineSpacing;\n")
+	if f.Grayscale() {
+		sb.WriteString("    const uint8_t  *data;\n")
+	} else {
+		sb.WriteString("    const uint16_t *data;\n")
+	}
+	sb.WriteString("    const uint8_t  *widths;\n")
+	sb.WriteString("    const uint8_t  *bearings;\n")
+	sb.WriteString("    const uint16_t *codes;\n")
+	sb.WriteString("} " + typ + ";\n")
+	sb.WriteString("#endif\n\n")
+
+	sb.WriteString(fmt.Sprintf("const %s %s_desc = {\n", typ, f.BaseName()))
+	sb.WriteString(fmt.Sprintf("    .width         = %d,\n", f.Width))
+	sb.WriteString(fmt.Sprintf("    .height        = %d,\n", f.Height))
+	if f.Depth > 1 {
+		sb.WriteString(fmt.Sprintf("    .bpp           = %d,\n", f.Depth))
+	}
+	sb.WriteString(fmt.Sprintf("    .bytesPerGlyph = %d,\n", glyphBytes(f)))
+	sb.WriteString(fmt.Sprintf("    .firstChar     = %d,\n", exportFirstChar(f, opt)))
+	sb.WriteString(fmt.Sprintf("    .lastChar      = %d,\n", lastChar(f, opt)))
+	sb.WriteString(fmt.Sprintf("    .glyphCount    = %d,\n", len(exportIndices(f, opt))))
+	sb.WriteString(fmt.Sprintf("    .baseline      = %d,\n", f.Metrics.Baseline))
+	sb.WriteString(fmt.Sprintf("    .ascent        = %d,\n", f.Metrics.Ascent))
+	sb.WriteString(fmt.Sprintf("    .descent       = %d,\n", f.Metrics.Descent))
+	sb.WriteString(fmt.Sprintf("    .xHeight       = %d,\n", f.Metrics.XHeight))
+	sb.WriteString(fmt.Sprintf("    .capHeight     = %d,\n", f.Metrics.CapHeight))
+	sb.WriteString(fmt.Sprintf("    .lineSpacing   = %d,\n", f.Metrics.LineSpacing))
+	sb.WriteString(fmt.Sprintf("    .data          = %s,\n", f.BaseName()))
+	if f.Proportional() {
+		sb.WriteString(fmt.Sprintf("    .widths        = %s_widths,\n", f.BaseName()))
+		sb.WriteString(fmt.Sprintf("    .bearings      = %s_bearings,\n", f.BaseName()))
+	} else {
+		sb.WriteString("    .widths        = 0,\n")
+		sb.WriteString("    .bearings      = 0,\n")
+	}
+	if exportContiguous(f, opt) {
+		sb.WriteString("    .codes         = 0,\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("    .codes         = %s_codes,\n", f.BaseName()))
+	}
+	sb.WriteString("};\n")
+
+	return sb.String()
+}
+
+// generateDescriptorRust generuje strukturę i statyczną instancję dla Rust
+func generateDescriptorRust(f *Font, opt exportOptions) string {
+	var sb strings.Builder
+	typ := fontDescriptorType(f, opt)
+	name := strings.ToUpper(f.BaseName())
+
+	sb.WriteString("\npub struct " + typ + " {\n")
+	sb.WriteString("    pub width: u8,\n")
+	sb.WriteString("    pub height: u8,\n")
+	if f.Depth > 1 {
+		sb.WriteString("    pub bpp: u8,\n")
+	}
+	sb.WriteString("    pub bytes_per_glyph: u16,\n")
+	sb.WriteString("    pub first_char: u16,\n")
+	sb.WriteString("    pub last_char: u16,\n")
+	sb.WriteString("    pub glyph_count: u16,\n")
+	sb.WriteString("    pub baseline: u8,\n")
+	sb.WriteString("    pub ascent: u8,\n")
+	sb.WriteString("    pub descent: u8,\n")
+	sb.WriteString("    pub x_height: u8,\n")
+	sb.WriteString("    pub cap_height: u8,\n")
+	sb.WriteString("    pub line_spacing: u8,\n")
+	if f.Grayscale() {
+		sb.WriteString("    pub data: &'static [u8],\n")
+	} else {
+		sb.WriteString("    pub data: &'static [u16],\n")
+	}
+	sb.WriteString("    pub widths: Option<&'static [u8]>,\n")
+	sb.WriteString("    pub bearings: Option<&'static [u8]>,\n")
+	sb.WriteString("    pub codes: Option<&'static [u16]>,\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString(fmt.Sprintf("pub static %s_DESC: %s = %s {\n", name, typ, typ))
+	sb.WriteString(fmt.Sprintf("    width: %d,\n", f.Width))
+	sb.WriteString(fmt.Sprintf("    height: %d,\n", f.Height))
+	if f.Depth > 1 {
+		sb.WriteString(fmt.Sprintf("    bpp: %d,\n", f.Depth))
+	}
+	sb.WriteString(fmt.Sprintf("    bytes_per_glyph: %d,\n", glyphBytes(f)))
+	sb.WriteString(fmt.Sprintf("    first_char: %d,\n", exportFirstChar(f, opt)))
+	sb.WriteString(fmt.Sprintf("    last_char: %d,\n", lastChar(f, opt)))
+	sb.WriteString(fmt.Sprintf("    glyph_count: %d,\n", len(exportIndices(f, opt))))
+	sb.WriteString(fmt.Sprintf("    baseline: %d,\n", f.Metrics.Baseline))
+	sb.WriteString(fmt.Sprintf("    ascent: %d,\n", f.Metrics.Ascent))
+	sb.WriteString(fmt.Sprintf("    descent: %d,\n", f.Metrics.Descent))
+	sb.WriteString(fmt.Sprintf("    x_height: %d,\n", f.Metrics.XHeight))
+	sb.WriteString(fmt.Sprintf("    cap_height: %d,\n", f.Metrics.CapHeight))
+	sb.WriteString(fmt.Sprintf("    line_spacing: %d,\n", f.Metrics.LineSpacing))
+	sb.WriteString(fmt.Sprintf("    data: &%s,\n", name))
+	if f.Proportional() {
+		sb.WriteString(fmt.Sprintf("    widths: Some(&%s_WIDTHS),\n", name))
+		sb.WriteString(fmt.Sprintf("    bearings: Some(&%s_BEARINGS),\n", name))
+	} else {
+		sb.WriteString("    widths: None,\n")
+		sb.WriteString("    bearings: None,\n")
+	}
+	if exportContiguous(f, opt) {
+		sb.WriteString("    codes: None,\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("    codes: Some(&%s_CODES),\n", name))
+	}
+	sb.WriteString("};\n")
+
+	return sb.String()
+}
+
+// generateDescriptorGo generuje typ struktury i zmienną z opisem fontu dla Go
+func generateDescriptorGo(f *Font, opt exportOptions) string {
+	var sb strings.Builder
+	typ := fontDescriptorType(f, opt)
+	name := goFontName(f)
+
+	sb.WriteString("\ntype " + typ + " struct {\n")
+	sb.WriteString("\tWidth         int\n")
+	sb.WriteString("\tHeight        int\n")
+	if f.Depth > 1 {
+		sb.WriteString("\tBpp           int\n")
+	}
+	sb.WriteString("\tBytesPerGlyph int\n")
+	sb.WriteString("\tFirstChar     rune\n")
+	sb.WriteString("\tLastChar      rune\n")
+	sb.WriteString("\tGlyphCount    int\n")
+	sb.WriteString("\tBaseline      int\n")
+	sb.WriteString("\tAscent        int\n")
+	sb.WriteString("\tDescent       int\n")
+	sb.WriteString("\tXHeight       int\n")
+	sb.WriteString("\tCapHeight     int\n")
+	sb.WriteString("\tLineSpacing   int\n")
+	if f.Grayscale() {
+		sb.WriteString("\tData          []uint8\n")
+	} else {
+		sb.WriteString("\tData          []uint16\n")
+	}
+	sb.WriteString("\tWidths        []uint8\n")
+	sb.WriteString("\tBearings      []uint8\n")
+	sb.WriteString("\tCodes         []uint16\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString(fmt.Sprintf("var %sDesc = %s{\n", name, typ))
+	sb.WriteString(fmt.Sprintf("\tWidth:         %d,\n", f.Width))
+	sb.WriteString(fmt.Sprintf("\tHeight:        %d,\n", f.Height))
+	if f.Depth > 1 {
+		sb.WriteString(fmt.Sprintf("\tBpp:           %d,\n", f.Depth))
+	}
+	sb.WriteString(fmt.Sprintf("\tBytesPerGlyph: %d,\n", glyphBytes(f)))
+	sb.WriteString(fmt.Sprintf("\tFirstChar:     %d,\n", exportFirstChar(f, opt)))
+	sb.WriteString(fmt.Sprintf("\tLastChar:      %d,\n", lastChar(f, opt)))
+	sb.WriteString(fmt.Sprintf("\tGlyphCount:    %d,\n", len(exportIndices(f, opt))))
+	sb.WriteString(fmt.Sprintf("\tBaseline:      %d,\n", f.Metrics.Baseline))
+	sb.WriteString(fmt.Sprintf("\tAscent:        %d,\n", f.Metrics.Ascent))
+	sb.WriteString(fmt.Sprintf("\tDescent:       %d,\n", f.Metrics.Descent))
+	sb.WriteString(fmt.Sprintf("\tXHeight:       %d,\n", f.Metrics.XHeight))
+	sb.WriteString(fmt.Sprintf("\tCapHeight:     %d,\n", f.Metrics.CapHeight))
+	sb.WriteString(fmt.Sprintf("\tLineSpacing:   %d,\n", f.Metrics.LineSpacing))
+	sb.WriteString(fmt.Sprintf("\tData:          %s,\n", name))
+	if f.Proportional() {
+		sb.WriteString(fmt.Sprintf("\tWidths:        %sWidths,\n", name))
+		sb.WriteString(fmt.Sprintf("\tBearings:      %sBearings,\n", name))
+	}
+	if !exportContiguous(f, opt) {
+		sb.WriteString(fmt.Sprintf("\tCodes:         %sCodes,\n", name))
+	}
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
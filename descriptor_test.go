@@ -0,0 +1,112 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// Kilka fontów wyeksportowanych do jednego pakietu Go nie deklaruje dwa razy tego samego identyfikatora
+func TestDescriptorGoPackage(t *testing.T) {
+	opt := exportOptions{Descriptor: true, DescriptorType: "FontDef"}
+	fonts := []*Font{testFont(t, 8, 8, 1), testFont(t, 8, 16, 1), testFont(t, 6, 9, 4)}
+	seen := map[string]int{}
+	for i, f := range fonts {
+		src := generateFontSource(f, formatGo, opt)
+		file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.SkipObjectResolution)
+		if err != nil {
+			t.Fatalf("%s: %v\n%s", goFontName(f), err, src)
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				var names []*ast.Ident
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					names = []*ast.Ident{s.Name}
+				case *ast.ValueSpec:
+					names = s.Names
+				}
+				for _, n := range names {
+					if j, dup := seen[n.Name]; dup && j != i {
+						t.Errorf("%s declared by fonts %d and %d", n.Name, j, i)
+					}
+					seen[n.Name] = i
+				}
+			}
+		}
+	}
+	if _, ok := seen["Font8x16FontDef"]; !ok {
+		t.Errorf("no Font8x16FontDef type, got %v", seen)
+	}
+}
+
+// Struktura Rust nosi nazwę fontu, typ C zostaje wspólny (chroniony #ifndef)
+func TestDescriptorTypeNames(t *testing.T) {
+	opt := exportOptions{Descriptor: true, DescriptorType: "Glyphs"}
+	tests := []struct {
+		format string
+		depth  int
+		re     string
+		want   string
+	}{
+		{formatC, 1, `\} (\w+);`, "Glyphs"},
+		{formatC, 4, `\} (\w+);`, "GlyphsGray"},
+		{formatRust, 1, `pub struct (\w+)`, "Font8x8Glyphs"},
+		{formatRust, 2, `pub struct (\w+)`, "Font8x8GlyphsGray"},
+		{formatGo, 1, `type (\w+) struct`, "Font8x8Glyphs"},
+		{formatGo, depthRGB565, `type (\w+) struct`, "Icons8x8GlyphsIcon"},
+	}
+	for _, tt := range tests {
+		src := generateFontSource(testFont(t, 8, 8, tt.depth), tt.format, opt)
+		m := regexp.MustCompile(tt.re).FindAllStringSubmatch(src, -1)
+		if len(m) != 1 || m[0][1] != tt.want {
+			t.Errorf("%s depth %d: types %v, want [%s]", tt.format, tt.depth, m, tt.want)
+		}
+	}
+}
+
+// Plik z deskryptorem wczytuje się z powrotem razem z metrykami
+func TestDescriptorRoundTrip(t *testing.T) {
+	fonts := []struct {
+		name string
+		font func(t *testing.T) *Font
+	}{
+		{"1bpp", func(t *testing.T) *Font { return testFont(t, 8, 8, 1) }},
+		{"4bpp", func(t *testing.T) *Font { return testFont(t, 6, 8, 4) }},
+		{"proportional sparse", func(t *testing.T) *Font {
+			f := testFont(t, 8, 8, 1)
+			f.SetProportional(true)
+			f.DeleteGlyph(f.Index('B'), false)
+			return f
+		}},
+	}
+	for _, ff := range fonts {
+		t.Run(ff.name, func(t *testing.T) {
+			roundTrip(t, ff.font(t), exportOptions{Art: true, Descriptor: true, DescriptorType: "FontDef"})
+		})
+	}
+}
+
+// Zakres i liczba znaków w deskryptorze opisują zapisany podzbiór, nie cały font
+func TestDescriptorSubset(t *testing.T) {
+	f := testFont(t, 8, 8, 1)
+	indices, _ := subsetIndices(f, charsOfText("0123"))
+	src := generateDescriptorC(f, exportOptions{Glyphs: indices, Descriptor: true})
+	for _, want := range []string{".firstChar     = 48,", ".lastChar      = 51,", ".glyphCount    = 4,", ".codes         = 0,"} {
+		if !strings.Contains(src, want) {
+			t.Errorf("missing %q\n%s", want, src)
+		}
+	}
+	indices, _ = subsetIndices(f, charsOfText("AZ"))
+	src = generateDescriptorC(f, exportOptions{Glyphs: indices, Descriptor: true})
+	if !strings.Contains(src, ".lastChar      = 90,") || !strings.Contains(src, ".codes         = FONT_8x8_codes,") {
+		t.Errorf("sparse subset:\n%s", src)
+	}
+}
@@ -2,7 +2,7 @@
 
     Edytor Glifu
     Wszystkie funkcje związane z oknem edycji pojedynczego znaku
-    – prostokąty pikseli, kliknięcia, przesunięcia, UNDO/REDO, zapisywanie glifu
+    – prostokąty pikseli, malowanie myszą (paint.go), przesunięcia, UNDO/REDO, zapisywanie glifu
 
 =========================================================================== */
 
@@ -10,6 +10,7 @@ package main
 
 import (
 	"fmt"
+	"image"
 	"image/color"
 	"strconv"
 	"strings"
@@ -17,28 +18,118 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
 )
 
+var showGrid = true // zmienna dla siatki w oknie edycji
+
+// glyphEditor przechowuje stan okna edycji jednego glifu
+type glyphEditor struct {
+	font    *Font
+	history *History
+	index   int // indeks edytowanego glifu
+
+	xShift, yShift       int  // przesunięcia podglądu (zatwierdzane przy zapisie)
+	sliderInternalUpdate bool // flaga blokująca Push podczas aktualizacji sliderów
+
+	win       fyne.Window
+	grid      *fyne.Container       // kontener z prostokątami
+	rects     [][]*canvas.Rectangle // prostokąty reprezentujące piksele
+	imgRaster *canvas.Raster        // podgląd w głównym oknie
+	onChange  func()                // wywoływane po każdej zmianie glifu (podgląd napisu)
+
+	// Font proporcjonalny: znaczniki lewej i prawej krawędzi glifu
+	pixelSize    float32
+	leftMarker   *edgeMarker
+	rightMarker  *edgeMarker
+	metricsLabel *widget.Label
+	dragging     bool // trwa przeciąganie znacznika lub pociągnięcie pędzla (jeden wpis UNDO)
+
+	level int // poziom jasności rysowany pędzlem (odcienie szarości, kolor RGB565)
+
+	guides []*canvas.Line // linie metryk: bazowa, x, wersaliki, ascent, descent
+
+	sel     image.Rectangle   // zaznaczenie w pikselach glifu (clip.go), puste = brak
+	selRect *canvas.Rectangle // ramka zaznaczenia nad siatką
+}
+
+// Kolory linii metryk w kolejności ed.guides
+var guideColors = []color.Color{
+	color.NRGBA{R: 220, A: 200},         // linia bazowa
+	color.NRGBA{G: 160, A: 180},         // wysokość x
+	color.NRGBA{B: 220, A: 180},         // wysokość wersalików
+	color.NRGBA{R: 150, B: 150, A: 140}, // ascent
+	color.NRGBA{R: 150, B: 150, A: 140}, // descent
+}
+
+// isOpen zwraca true, jeśli okno edycji jest otwarte
+func (ed *glyphEditor) isOpen() bool {
+	return ed != nil && ed.win != nil
+}
+
+// glyph zwraca aktualnie edytowany glif
+func (ed *glyphEditor) glyph() Glyph {
+	return ed.font.Glyph(ed.index)
+}
+
+// shiftedLevels zwraca poziomy pikseli glifu po przesunięciu o (dx, dy)
+// (dodatnie dx w prawo, dodatnie dy w dół; piksele poza komórką są tracone)
+func shiftedLevels(g Glyph, dx, dy int) []uint16 {
+	f := g.Font
+	tmp := make([]uint16, f.Width*f.Height)
+	for y := 0; y < f.Height; y++ {
+		for x := 0; x < f.Width; x++ {
+			tmp[y*f.Width+x] = uint16(g.Level(x-dx, y-dy))
+		}
+	}
+	return tmp
+}
+
+// setRectColor ustawia kolor prostokąta piksela według poziomu jasności;
+// puste piksele poza szerokością glifu proporcjonalnego są szare
+func setRectColor(rect *canvas.Rectangle, f *Font, level int, outside bool) {
+	if level == 0 && outside {
+		rect.FillColor = color.Gray{Y: 220}
+	} else {
+		rect.FillColor = f.PixelColor(level)
+	}
+	rect.Refresh()
+}
+
+// changed odświeża podglądy w głównym oknie po zmianie glifu
+func (ed *glyphEditor) changed() {
+	ed.imgRaster.Refresh()
+	if ed.onChange != nil {
+		ed.onChange()
+	}
+}
+
 // Otwiera okno edycji glifu
-func openEditWindow(currentIndex int, imgRaster *canvas.Raster) {
+func openEditWindow(f *Font, history *History, currentIndex int, imgRaster *canvas.Raster) *glyphEditor {
 
-	if len(fontData) == 0 || glyphW == 0 || glyphH == 0 {
-		return
+	if f.Empty() {
+		return nil
 	}
 
-	editWin = fyne.CurrentApp().NewWindow(fmt.Sprintf(T("editWindowTitle"), currentIndex))
+	ed := &glyphEditor{font: f, history: history, index: currentIndex, imgRaster: imgRaster, level: f.MaxLevel()}
+	ed.win = fyne.CurrentApp().NewWindow(fmt.Sprintf(T("editWindowTitle"), currentIndex))
+	ed.win.SetOnClosed(func() {
+		ed.win = nil
+		imgRaster.Refresh()
+	})
 
 	pixelSize := 20.0
-	gridWidth := float32(float64(glyphW) * pixelSize)
-	gridHeight := float32(float64(glyphH) * pixelSize)
+	ed.pixelSize = float32(pixelSize)
+	gridWidth := float32(float64(f.Width) * pixelSize)
+	gridHeight := float32(float64(f.Height) * pixelSize)
 
-	editGrid = container.NewWithoutLayout()
-	rects = make([][]*canvas.Rectangle, glyphH)
+	ed.grid = container.NewWithoutLayout()
+	ed.rects = make([][]*canvas.Rectangle, f.Height)
 
-	for y := 0; y < glyphH; y++ {
-		rects[y] = make([]*canvas.Rectangle, glyphW)
-		for x := 0; x < glyphW; x++ {
+	for y := 0; y < f.Height; y++ {
+		ed.rects[y] = make([]*canvas.Rectangle, f.Width)
+		for x := 0; x < f.Width; x++ {
 			xx, yy := x, y
 			rect := canvas.NewRectangle(color.White)
 			if showGrid {
@@ -51,85 +142,156 @@ func openEditWindow(currentIndex int, imgRaster *canvas.Raster) {
 			rect.Move(fyne.NewPos(float32(xx)*float32(pixelSize), float32(yy)*float32(pixelSize)))
 
 			// inicjalizacja koloru
-			row := fontData[currentIndex*glyphH+yy]
-			if (row>>(glyphW-1-xx))&1 != 0 {
-				rect.FillColor = color.Black
-			}
-			rects[yy][xx] = rect
-			editGrid.Add(rect)
-
-			// Klikalny przycisk nad prostokątem
-			btn := widget.NewButton("", func(xx, yy int) func() {
-				return func() {
-					pushUndo(currentIndex)
-					row := fontData[currentIndex*glyphH+yy]
-					row ^= 1 << (glyphW - 1 - xx)
-					fontData[currentIndex*glyphH+yy] = row
-					if (row>>(glyphW-1-xx))&1 != 0 {
-						rects[yy][xx].FillColor = color.Black
+			rect.FillColor = f.PixelColor(ed.glyph().Level(xx, yy))
+			ed.rects[yy][xx] = rect
+			ed.grid.Add(rect)
+
+		}
+	}
+
+	// Warstwa malowania myszą nad prostokątami (paint.go)
+	paint := newPaintCanvas(ed)
+	paint.Resize(fyne.NewSize(gridWidth, gridHeight))
+	ed.grid.Add(paint)
+	ed.selRect = newSelectionRect()
+	ed.grid.Add(ed.selRect)
+
+	// Linie metryk fontu nad siatką
+	for _, c := range guideColors {
+		line := canvas.NewLine(c)
+		line.StrokeWidth = 2
+		ed.guides = append(ed.guides, line)
+		ed.grid.Add(line)
+	}
+	ed.updateGuides()
+
+	// Znaczniki krawędzi glifu - przeciąganie zmienia odstęp z lewej i szerokość
+	ed.leftMarker = newEdgeMarker(color.NRGBA{B: 220, A: 160}, func(x float32) {
+		g := ed.beginDrag()
+		right := g.Bearing() + g.Advance()
+		col := min(ed.markerColumn(x), right-1)
+		g.SetMetrics(col, right-col)
+		ed.updateMetrics()
+		ed.changed()
+	}, func() { ed.dragging = false })
+	ed.rightMarker = newEdgeMarker(color.NRGBA{R: 220, A: 160}, func(x float32) {
+		g := ed.beginDrag()
+		g.SetMetrics(g.Bearing(), ed.markerColumn(x)-g.Bearing())
+		ed.updateMetrics()
+		ed.changed()
+	}, func() { ed.dragging = false })
+	for _, m := range []*edgeMarker{ed.leftMarker, ed.rightMarker} {
+		m.Resize(fyne.NewSize(6, gridHeight))
+		ed.grid.Add(m)
+	}
+
+	// Szerokość glifu i przycisk liczenia jej z tuszu
+	ed.metricsLabel = widget.NewLabel("")
+	autoWidthBtn := widget.NewButton(T("autoWidth"), func() {
+		g := ed.glyph()
+		ed.history.Push(g, ed.xShift, ed.yShift)
+		g.AutoWidth()
+		ed.updateMetrics()
+		ed.changed()
+	})
+	ed.updateMetrics()
+
+	// Paleta poziomów jasności (odcienie szarości) lub wybór koloru (ikony RGB565)
+	palette := container.NewHBox()
+	if f.RGB565() {
+		swatch := canvas.NewRectangle(f.PixelColor(ed.level))
+		swatch.SetMinSize(fyne.NewSize(24, 24))
+		valueLabel := widget.NewLabel(fmt.Sprintf("0x%04X", ed.level))
+		colorBtn := widget.NewButton(T("iconColor"), func() {
+			picker := dialog.NewColorPicker(T("iconColor"), T("iconColorMsg"), func(c color.Color) {
+				ed.level = int(colorToRGB565(c))
+				swatch.FillColor = f.PixelColor(ed.level)
+				swatch.Refresh()
+				valueLabel.SetText(fmt.Sprintf("0x%04X", ed.level))
+			}, ed.win)
+			picker.Advanced = true
+			picker.SetColor(f.PixelColor(ed.level))
+			picker.Show()
+		})
+		palette.Add(container.NewCenter(swatch))
+		palette.Add(colorBtn)
+		palette.Add(valueLabel)
+	} else if f.Depth > 1 {
+		var swatches []*widget.Button
+		for l := 0; l <= f.MaxLevel(); l++ {
+			btn := widget.NewButton(strconv.Itoa(l), nil)
+			btn.OnTapped = func() {
+				ed.level = l
+				for i, b := range swatches {
+					if i == l {
+						b.Importance = widget.HighImportance
 					} else {
-						rects[yy][xx].FillColor = color.White
+						b.Importance = widget.MediumImportance
 					}
-					rects[yy][xx].Refresh()
-					imgRaster.Refresh()
+					b.Refresh()
 				}
-			}(xx, yy))
-			btn.Importance = widget.LowImportance
-			btn.Resize(fyne.NewSize(float32(pixelSize), float32(pixelSize)))
-			btn.Move(fyne.NewPos(float32(xx)*float32(pixelSize), float32(yy)*float32(pixelSize)))
-			editGrid.Add(btn)
+			}
+			if l == ed.level {
+				btn.Importance = widget.HighImportance
+			}
+			swatches = append(swatches, btn)
+			swatch := canvas.NewRectangle(f.PixelColor(l))
+			swatch.SetMinSize(fyne.NewSize(12, 12))
+			palette.Add(container.NewVBox(swatch, btn))
 		}
 	}
 
+	// Kopiowanie zaznaczenia (lub całego glifu) i wklejanie ze schowka
+	copyBtn := widget.NewButton(T("copy"), ed.copySelection)
+	pasteBtn := widget.NewButton(T("paste"), func() {
+		if glyphClipboard == nil {
+			dialog.ShowInformation(T("paste"), T("clipboardEmpty"), ed.win)
+			return
+		}
+		ed.pasteClipboard()
+	})
+
+	// Schowek systemowy: glif jako rysunek ASCII lub linia hex C (glyphtext.go)
+	copyArtBtn := widget.NewButton(T("copyArt"), func() {
+		fyne.CurrentApp().Clipboard().SetContent(glyphArtText(ed.glyph()))
+	})
+	copyHexBtn := widget.NewButton(T("copyHex"), func() {
+		fyne.CurrentApp().Clipboard().SetContent(glyphHexLine(ed.glyph()))
+	})
+	pasteTextBtn := widget.NewButton(T("pasteText"), func() {
+		levels, err := parseGlyphText(f, fyne.CurrentApp().Clipboard().Content())
+		if err != nil {
+			dialog.ShowError(err, ed.win)
+			return
+		}
+		g := ed.glyph()
+		ed.history.Push(g, ed.xShift, ed.yShift)
+		g.SetLevels(levels)
+		ed.refreshGrid()
+	})
+
 	// Checkbox - pokaż siatkę
 	gridCheck := widget.NewCheck(T("showGrid"), func(val bool) {
 		showGrid = val
-		for y := 0; y < glyphH; y++ {
-			for x := 0; x < glyphW; x++ {
+		for y := 0; y < f.Height; y++ {
+			for x := 0; x < f.Width; x++ {
 				if showGrid {
-					rects[y][x].StrokeWidth = 1
-					rects[y][x].StrokeColor = color.Gray{Y: 128}
+					ed.rects[y][x].StrokeWidth = 1
+					ed.rects[y][x].StrokeColor = color.Gray{Y: 128}
 				} else {
-					rects[y][x].StrokeWidth = 0
+					ed.rects[y][x].StrokeWidth = 0
 				}
-				rects[y][x].Refresh()
+				ed.rects[y][x].Refresh()
 			}
 		}
 	})
 	gridCheck.SetChecked(showGrid)
 
-	// Funkcja pomocnicza do przesunięcia bitów w wierszu
-	shiftRow := func(row uint16, shift int, width int) uint16 {
-		if shift > 0 {
-			return (row << shift) & ((1 << width) - 1)
-		} else if shift < 0 {
-			return row >> (-shift)
-		}
-		return row
-	}
-
-	// Funkcja odświeżająca prostokąty w edycji z uwzględnieniem przesunięcia
-	refreshGrid := func() {
-		tmp := make([]uint16, glyphH)
-		for y := 0; y < glyphH; y++ {
-			newY := y + yShift
-			if newY >= 0 && newY < glyphH {
-				tmp[newY] = shiftRow(fontData[currentIndex*glyphH+y], -xShift, glyphW)
-			}
-		}
-		for y := 0; y < glyphH; y++ {
-			row := tmp[y]
-			for x := 0; x < glyphW; x++ {
-				if (row>>(glyphW-1-x))&1 != 0 {
-					rects[y][x].FillColor = color.Black
-				} else {
-					rects[y][x].FillColor = color.White
-				}
-				rects[y][x].Refresh()
-			}
-		}
-		imgRaster.Refresh()
-	}
+	// Checkbox - rysunek ASCII w podglądzie C
+	artCheck := widget.NewCheck(T("asciiArt"), func(val bool) {
+		savePrefs.Art = val
+	})
+	artCheck.SetChecked(savePrefs.Art)
 
 	// Strzałki dla suwaków
 	leftArrow := canvas.NewText("◀️", color.Black)
@@ -146,29 +308,29 @@ func openEditWindow(currentIndex int, imgRaster *canvas.Raster) {
 	downArrow.Resize(fyne.NewSize(32, 32))
 
 	// Slider X
-	xSlider := widget.NewSlider(float64(-(glyphW - 1)), float64(glyphW-1))
+	xSlider := widget.NewSlider(float64(-(f.Width - 1)), float64(f.Width-1))
 	xSlider.Value = 0
 	xSlider.Step = 1
 	xSlider.OnChanged = func(val float64) {
-		if sliderInternalUpdate {
+		if ed.sliderInternalUpdate {
 			return
 		}
-		pushUndo(currentIndex)
-		xShift = int(val)
-		refreshGrid()
+		ed.history.Push(ed.glyph(), ed.xShift, ed.yShift)
+		ed.xShift = int(val)
+		ed.refreshGrid()
 	}
 
 	// Slider Y
-	ySlider := widget.NewSlider(float64(-(glyphH - 1)), float64(glyphH-1))
+	ySlider := widget.NewSlider(float64(-(f.Height - 1)), float64(f.Height-1))
 	ySlider.Value = 0
 	ySlider.Step = 1
 	ySlider.OnChanged = func(val float64) {
-		if sliderInternalUpdate {
+		if ed.sliderInternalUpdate {
 			return
 		}
-		pushUndo(currentIndex)
-		yShift = int(val)
-		refreshGrid()
+		ed.history.Push(ed.glyph(), ed.xShift, ed.yShift)
+		ed.yShift = int(val)
+		ed.refreshGrid()
 	}
 
 	// Dodanie strzałek
@@ -187,54 +349,48 @@ func openEditWindow(currentIndex int, imgRaster *canvas.Raster) {
 		ySlider,
 	)
 
+	// Ustawia slidery bez zapisywania stanu do UNDO
+	syncSliders := func() {
+		ed.sliderInternalUpdate = true
+		xSlider.SetValue(float64(ed.xShift))
+		ySlider.SetValue(float64(ed.yShift))
+		ed.sliderInternalUpdate = false
+	}
+
 	// Przycisk UNDO/REDO
 	undoBtn := widget.NewButton(T("undo"), func() {
-		if undo(currentIndex) {
-			sliderInternalUpdate = true
-			xSlider.SetValue(float64(xShift))
-			ySlider.SetValue(float64(yShift))
-			sliderInternalUpdate = false
-			refreshGrid()
+		var ok bool
+		// stan całego fontu zamyka okno (History.OnFont) - siatka już nieaktualna
+		if ed.xShift, ed.yShift, ok = ed.history.Undo(f, ed.xShift, ed.yShift); ok && ed.isOpen() {
+			syncSliders()
+			ed.refreshGrid()
 		}
 	})
 	redoBtn := widget.NewButton(T("redo"), func() {
-		if redo(currentIndex) {
-			sliderInternalUpdate = true
-			xSlider.SetValue(float64(xShift))
-			ySlider.SetValue(float64(yShift))
-			sliderInternalUpdate = false
-			refreshGrid()
+		var ok bool
+		if ed.xShift, ed.yShift, ok = ed.history.Redo(f, ed.xShift, ed.yShift); ok && ed.isOpen() {
+			syncSliders()
+			ed.refreshGrid()
 		}
 	})
 
 	// Przycisk zapisu glifu
 	saveBtn := widget.NewButton(T("save"), func() {
-		if xShift != 0 || yShift != 0 {
-			tmp := make([]uint16, glyphH)
-			for y := 0; y < glyphH; y++ {
-				newY := y + yShift
-				if newY >= 0 && newY < glyphH {
-					tmp[newY] = shiftRow(fontData[currentIndex*glyphH+y], xShift, glyphW)
-				}
-			}
-			for y := 0; y < glyphH; y++ {
-				fontData[currentIndex*glyphH+y] = tmp[y]
-			}
+		g := ed.glyph()
+		if ed.xShift != 0 || ed.yShift != 0 {
+			g.SetLevels(shiftedLevels(g, ed.xShift, ed.yShift))
+			ed.xShift, ed.yShift = 0, 0
 		}
 
 		var sb strings.Builder
 		sb.WriteString(T("editedCharAscii"))
-		sb.WriteString(fmt.Sprintf("'%c'\n", currentIndex+32))
-		for y := 0; y < glyphH; y++ {
-			row := fontData[currentIndex*glyphH+y]
-			sb.WriteString(fmt.Sprintf("0x%04X", row))
-			if y < glyphH-1 {
-				sb.WriteString(",")
-			}
+		sb.WriteString(fmt.Sprintf("'%c'\n", f.Rune(g.Code())))
+		if savePrefs.Art {
+			writeGlyphArt(&sb, g, "", "/*")
 		}
-		sb.WriteString(fmt.Sprintf(", // '%c'\n", currentIndex+32))
+		sb.WriteString(glyphHexLine(g) + "\n")
 
-		previewWin := fyne.CurrentApp().NewWindow(fmt.Sprintf(T("previewTitle"), currentIndex))
+		previewWin := fyne.CurrentApp().NewWindow(fmt.Sprintf(T("previewTitle"), ed.index))
 		previewEntry := widget.NewMultiLineEntry()
 		previewEntry.SetText(sb.String())
 		previewEntry.Wrapping = fyne.TextWrapBreak
@@ -242,12 +398,15 @@ func openEditWindow(currentIndex int, imgRaster *canvas.Raster) {
 			previewEntry,
 			widget.NewButton(T("close"), func() { previewWin.Close() }),
 		))
-		previewWin.Resize(fyne.NewSize(900, 120))
+		if savePrefs.Art {
+			previewWin.Resize(fyne.NewSize(900, float32(120+f.Height*20)))
+		} else {
+			previewWin.Resize(fyne.NewSize(900, 120))
+		}
 		previewWin.Show()
 
-		editWin.Close()
-		editWin = nil
-		imgRaster.Refresh()
+		ed.win.Close()
+		ed.changed()
 	})
 
 	content := container.NewBorder(
@@ -255,42 +414,109 @@ func openEditWindow(currentIndex int, imgRaster *canvas.Raster) {
 		container.NewVBox(
 			xSliderWithArrows,
 			ySliderWithArrows,
+			container.NewHBox(ed.metricsLabel, autoWidthBtn),
+			container.NewHScroll(container.NewHBox(toolPalette(), copyBtn, pasteBtn)),
+			container.NewHScroll(container.NewHBox(copyArtBtn, copyHexBtn, pasteTextBtn)),
+			container.NewHScroll(ed.transformButtons()),
+			container.NewHScroll(palette),
 			saveBtn,
-			container.NewHBox(undoBtn, redoBtn, gridCheck),
+			container.NewHBox(undoBtn, redoBtn, gridCheck, artCheck),
 		),
 		nil,
 		nil,
-		editGrid,
+		ed.grid,
 	)
-	editWin.SetContent(content)
-	editWin.Resize(fyne.NewSize(gridWidth+8, gridHeight+200))
-	editWin.Show()
+	ed.win.SetContent(content)
+	ed.win.Resize(fyne.NewSize(gridWidth+8, gridHeight+200))
+	ed.win.Show()
+	return ed
 }
 
-// Aktualizacja prostokątów w edytorze po zmianie znaku w głównym oknie
-func updateEditorGrid(currentIndex int, imgRaster *canvas.Raster) {
-	if editWin != nil && editGrid != nil && len(rects) == glyphH {
-		for y := 0; y < glyphH; y++ {
-			for x := 0; x < glyphW; x++ {
-				row := fontData[currentIndex*glyphH+y]
-				if (row>>(glyphW-1-x))&1 != 0 {
-					rects[y][x].FillColor = color.Black
-				} else {
-					rects[y][x].FillColor = color.White
-				}
-				rects[y][x].Refresh()
+// refreshGrid odświeża prostokąty w edycji z uwzględnieniem przesunięcia
+func (ed *glyphEditor) refreshGrid() {
+	f := ed.font
+	g := ed.glyph()
+	tmp := shiftedLevels(g, ed.xShift, ed.yShift)
+	for y := 0; y < f.Height; y++ {
+		for x := 0; x < f.Width; x++ {
+			setRectColor(ed.rects[y][x], f, int(tmp[y*f.Width+x]), !g.InAdvance(x))
+		}
+	}
+	ed.updateMetrics()
+	ed.changed()
+}
+
+// updateGuides ustawia linie metryk fontu (po zmianie w panelu metryk)
+func (ed *glyphEditor) updateGuides() {
+	m := ed.font.Metrics
+	rows := []int{
+		m.Baseline,
+		m.Baseline - m.XHeight,
+		m.Baseline - m.CapHeight,
+		m.Baseline - m.Ascent,
+		m.Baseline + m.Descent,
+	}
+	width := float32(ed.font.Width) * ed.pixelSize
+	for i, line := range ed.guides {
+		y := float32(rows[i]) * ed.pixelSize
+		line.Position1 = fyne.NewPos(0, y)
+		line.Position2 = fyne.NewPos(width, y)
+		line.Refresh()
+	}
+}
+
+// beginDrag zapisuje stan do UNDO na początku przeciągania znacznika lub pociągnięcia pędzla
+func (ed *glyphEditor) beginDrag() Glyph {
+	g := ed.glyph()
+	if !ed.dragging {
+		ed.history.Push(g, ed.xShift, ed.yShift)
+		ed.dragging = true
+	}
+	return g
+}
+
+// markerColumn zamienia pozycję X znacznika na granicę kolumn siatki
+func (ed *glyphEditor) markerColumn(x float32) int {
+	return max(0, min(int(x/ed.pixelSize+0.5), ed.font.Width))
+}
+
+// updateMetrics ustawia znaczniki krawędzi, opis szerokości i szare tło poza glifem
+func (ed *glyphEditor) updateMetrics() {
+	g := ed.glyph()
+	b, a := g.Bearing(), g.Advance()
+	ed.leftMarker.Move(fyne.NewPos(float32(b)*ed.pixelSize-3, 0))
+	ed.rightMarker.Move(fyne.NewPos(float32(b+a)*ed.pixelSize-3, 0))
+	ed.metricsLabel.SetText(fmt.Sprintf(T("glyphMetrics"), a, b))
+	if ed.xShift == 0 && ed.yShift == 0 {
+		for y := 0; y < ed.font.Height; y++ {
+			for x := 0; x < ed.font.Width; x++ {
+				setRectColor(ed.rects[y][x], ed.font, g.Level(x, y), !g.InAdvance(x))
 			}
 		}
-		imgRaster.Refresh()
+	}
+}
+
+// Aktualizacja prostokątów w edytorze po zmianie znaku w głównym oknie
+func updateEditorGrid(ed *glyphEditor, currentIndex int) {
+	if ed.isOpen() && len(ed.rects) == ed.font.Height {
+		ed.index = currentIndex
+		ed.win.SetTitle(fmt.Sprintf(T("editWindowTitle"), currentIndex))
+		ed.refreshGrid()
 	}
 }
 
 // Aktualizacja tekstów w GUI po zmianie języka
-func updateMainTexts(btn, loadedFileLabel, label, editBtn, scaleLabel, saveAllBtn interface{}, currentIndex, scale int) {
+func updateMainTexts(btn, importBinBtn, importIconsBtn, newFontBtn, saveAllBtn, saveBackBtn, sourceSubsetBtn, saveBinBtn, metricsBtn, resizeCellBtn, transformBtn, effectsBtn interface{}) {
 	btn.(*widget.Button).SetText(T("chooseFile"))
-	loadedFileLabel.(*widget.Label).SetText(T("noFile"))
-	label.(*widget.Label).SetText(T("glyph") + ": " + strconv.Itoa(currentIndex))
-	editBtn.(*widget.Button).SetText(T("editGlyph"))
-	scaleLabel.(*widget.Label).SetText(T("scale") + ": " + strconv.Itoa(scale))
+	importBinBtn.(*widget.Button).SetText(T("importBinary"))
+	importIconsBtn.(*widget.Button).SetText(T("importIcons"))
+	newFontBtn.(*widget.Button).SetText(T("newFont"))
 	saveAllBtn.(*widget.Button).SetText(T("saveFont"))
+	saveBackBtn.(*widget.Button).SetText(T("saveBack"))
+	sourceSubsetBtn.(*widget.Button).SetText(T("sourceSubset"))
+	saveBinBtn.(*widget.Button).SetText(T("saveBinary"))
+	metricsBtn.(*widget.Button).SetText(T("metrics"))
+	resizeCellBtn.(*widget.Button).SetText(T("resizeCell"))
+	transformBtn.(*widget.Button).SetText(T("transform"))
+	effectsBtn.(*widget.Button).SetText(T("effects"))
 }
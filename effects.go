@@ -0,0 +1,196 @@
+/* ============================================================================
+
+    Efekty fontu
+    Generowanie wariantu całego fontu: pogrubienie o N pikseli, pochylenie
+    (przesunięcie wierszy), kontur i cień – wynik trafia do nowej zakładki,
+    oryginał zostaje bez zmian
+    – applyEffect, effectGrowth, effectsDialog
+
+    Komórka powiększana jest automatycznie (Font.Resize): pogrubienie
+    i pochylenie poszerzają ją w prawo, kontur o piksel z każdej strony,
+    cień w prawo i w dół. Pochylenie przesuwa wiersz o piksel w prawo co
+    N wierszy, licząc od dolnego wiersza komórki. Cień fontów w odcieniach
+    szarości i ikon ma połowę jasności tuszu.
+
+=========================================================================== */
+
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Efekty fontu
+const (
+	fxBold    = "bold"
+	fxItalic  = "italic"
+	fxOutline = "outline"
+	fxShadow  = "shadow"
+)
+
+var effects = []string{fxBold, fxItalic, fxOutline, fxShadow}
+
+// Skala podglądu efektu
+const effectPreviewScale = 3
+
+// effectGrowth zwraca powiększenie komórki (w prawo / w dół) i punkt zaczepienia
+// starej zawartości dla efektu o parametrze n
+func effectGrowth(f *Font, fx string, n int) (dw, dh int, anchor string) {
+	switch fx {
+	case fxBold:
+		return n, 0, anchorTopLeft
+	case fxItalic:
+		return (f.Height - 1) / n, 0, anchorTopLeft
+	case fxOutline:
+		return 2, 2, anchorCenter
+	case fxShadow:
+		return n, n, anchorTopLeft
+	}
+	return 0, 0, anchorTopLeft
+}
+
+// applyEffect zwraca nowy font z efektem fx o parametrze n (n >= 1)
+func applyEffect(f *Font, fx string, n int) (*Font, error) {
+	dw, dh, anchor := effectGrowth(f, fx, n)
+	if f.Width+dw > 16 {
+		return nil, fmt.Errorf(T("fxTooWide"), f.Width+dw)
+	}
+	out := f.Clone()
+	out.SourceText, out.SourceURI = "", nil
+	out.Resize(f.Width+dw, f.Height+dh, anchor)
+
+	for _, g := range out.Glyphs() {
+		src := copyRegion(g, glyphBounds(out)).Glyph(0)
+		for y := 0; y < out.Height; y++ {
+			for x := 0; x < out.Width; x++ {
+				g.SetLevel(x, y, effectLevel(src, fx, n, x, y))
+			}
+		}
+		// szerokość glifu proporcjonalnego rośnie razem z tuszem
+		if out.Proportional() {
+			switch fx {
+			case fxBold, fxShadow:
+				g.SetMetrics(g.Bearing(), g.Advance()+n)
+			case fxOutline:
+				g.SetMetrics(g.Bearing()-1, g.Advance()+2)
+			case fxItalic:
+				g.AutoWidth()
+			}
+		}
+	}
+	return out, nil
+}
+
+// effectLevel zwraca poziom piksela (x, y) po efekcie; src to glif przed efektem
+// (już w powiększonej komórce)
+func effectLevel(src Glyph, fx string, n, x, y int) int {
+	f := src.Font
+	level := src.Level(x, y)
+	switch fx {
+	case fxBold:
+		// najbliższy tusz z lewej w odległości do n pikseli
+		for k := 1; level == 0 && k <= n; k++ {
+			level = src.Level(x-k, y)
+		}
+	case fxItalic:
+		level = src.Level(x-(f.Height-1-y)/n, y)
+	case fxOutline:
+		// piksel tła sąsiadujący z tuszem (8 sąsiadów) - oryginał znika
+		if level > 0 {
+			return 0
+		}
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				level = max(level, src.Level(x+dx, y+dy))
+			}
+		}
+	case fxShadow:
+		if shadow := src.Level(x-n, y-n); level == 0 && shadow > 0 {
+			level = max(1, f.LevelOf(f.Intensity(shadow)/2))
+			if f.Depth <= 1 {
+				level = 1
+			}
+		}
+	}
+	return level
+}
+
+// Wywoływane przy kliknięciu "Efekty"; onCreated otwiera nowy font w zakładce
+func effectsDialog(w fyne.Window, f *Font, name string, onCreated func(f *Font, name string)) {
+	if f.Empty() {
+		dialog.ShowInformation(T("noData"), T("loadFirst"), w)
+		return
+	}
+
+	fxNames := make([]string, len(effects))
+	for i, fx := range effects {
+		fxNames[i] = T("fx_" + fx)
+	}
+	fxSelect := widget.NewSelect(fxNames, nil)
+	amountSelect := widget.NewSelect([]string{"1", "2", "3", "4"}, nil)
+	hintLabel := widget.NewLabel("")
+	sampleEntry := widget.NewEntry()
+	sampleEntry.SetText("Hello 123")
+
+	// Podgląd przykładowego napisu fontem z efektem
+	var preview *Font
+	var placed []placedGlyph
+	raster := canvas.NewRasterWithPixels(func(x, y, wR, hR int) color.Color {
+		if preview == nil {
+			return color.White
+		}
+		m := preview.Metrics
+		level := textLevel(preview, placed, x/effectPreviewScale, y/effectPreviewScale-m.Ascent+m.Baseline)
+		return preview.PixelColor(level)
+	})
+
+	update := func() {
+		fx := effects[max(0, indexOf(fxNames, fxSelect.Selected))]
+		n, _ := strconv.Atoi(amountSelect.Selected)
+		var err error
+		if preview, err = applyEffect(f, fx, max(n, 1)); err != nil {
+			hintLabel.SetText(err.Error())
+			raster.Refresh()
+			return
+		}
+		hintLabel.SetText(T("fxHint_"+fx) + " " + fmt.Sprintf(T("fxCell"), preview.Width, preview.Height))
+		var width int
+		placed, width = layoutText(preview, sampleEntry.Text)
+		m := preview.Metrics
+		height := max(m.LineSpacing, m.Ascent+m.Descent, 1)
+		raster.SetMinSize(fyne.NewSize(float32(width*effectPreviewScale), float32(height*effectPreviewScale)))
+		raster.Refresh()
+	}
+	fxSelect.OnChanged = func(string) { update() }
+	amountSelect.OnChanged = func(string) { update() }
+	sampleEntry.OnChanged = func(string) { update() }
+	fxSelect.SetSelected(fxNames[0])
+	amountSelect.SetSelected("1")
+
+	form := widget.NewForm(
+		widget.NewFormItem(T("fxEffect"), fxSelect),
+		widget.NewFormItem(T("fxAmount"), amountSelect),
+		widget.NewFormItem(T("fxSample"), sampleEntry),
+	)
+	content := container.NewVBox(form, hintLabel, container.NewHScroll(raster))
+	d := dialog.NewCustomConfirm(T("effects"), T("fxApply"), T("cancel"), content, func(ok bool) {
+		if !ok {
+			return
+		}
+		if preview == nil {
+			dialog.ShowInformation(T("effects"), hintLabel.Text, w)
+			return
+		}
+		onCreated(preview, name+" ["+fxSelect.Selected+"]")
+	}, w)
+	d.Resize(fyne.NewSize(520, 360))
+	d.Show()
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// Komórka rośnie o tyle, ile potrzebuje efekt: w prawo, w dół lub z każdej strony
+func TestEffectGrowth(t *testing.T) {
+	f := NewFont(8, 9, make([]uint16, 9))
+	tests := []struct {
+		fx     string
+		n      int
+		dw, dh int
+		anchor string
+	}{
+		{fxBold, 2, 2, 0, anchorTopLeft},
+		{fxItalic, 1, 8, 0, anchorTopLeft},
+		{fxItalic, 3, 2, 0, anchorTopLeft},
+		{fxOutline, 4, 2, 2, anchorCenter},
+		{fxShadow, 2, 2, 2, anchorTopLeft},
+	}
+	for _, tt := range tests {
+		dw, dh, anchor := effectGrowth(f, tt.fx, tt.n)
+		if dw != tt.dw || dh != tt.dh || anchor != tt.anchor {
+			t.Errorf("%s %d: +%d×+%d %s, want +%d×+%d %s", tt.fx, tt.n, dw, dh, anchor, tt.dw, tt.dh, tt.anchor)
+		}
+	}
+}
+
+func TestApplyEffect(t *testing.T) {
+	ring := []string{".#.", "#.#", ".#."}
+	tests := []struct {
+		name string
+		art  []string
+		fx   string
+		n    int
+		want []string
+	}{
+		{"bold 1", ring, fxBold, 1, []string{".##.", "####", ".##."}},
+		{"bold 2", []string{"#.."}, fxBold, 2, []string{"###.."}},
+		{"italic", ring, fxItalic, 1, []string{"...#.", ".#.#.", ".#..."}},
+		{"italic every 2 rows", []string{"#", "#", "#"}, fxItalic, 2, []string{".#", "#.", "#."}},
+		{"outline", []string{"#"}, fxOutline, 1, []string{"###", "#.#", "###"}},
+		{"shadow", []string{"#."}, fxShadow, 1, []string{"#..", ".#."}},
+		{"shadow under ink", []string{"##"}, fxShadow, 1, []string{"##.", ".##"}},
+	}
+	for _, tt := range tests {
+		f := artFont(tt.art...)
+		out, err := applyEffect(f, tt.fx, tt.n)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := glyphArt(out.Glyph(0)); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+		if got := glyphArt(f.Glyph(0)); !slices.Equal(got, tt.art) {
+			t.Errorf("%s: original changed to %q", tt.name, got)
+		}
+	}
+}
+
+// Cień w odcieniach szarości ma połowę jasności tuszu
+func TestApplyEffectGrayShadow(t *testing.T) {
+	f := NewGrayFont(1, 1, 4, levelsFrom("?"))
+	out, err := applyEffect(f, fxShadow, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out.Glyph(0).Levels(); !slices.Equal(got, levelsFrom("?0", "07")) {
+		t.Errorf("levels %v", got)
+	}
+}
+
+// Wynik jest nowym fontem: bez pliku źródłowego, z szerokościami powiększonymi o efekt
+func TestApplyEffectFont(t *testing.T) {
+	f := testFont(t, 6, 8, 1)
+	f.SourceText = "const uint16_t FONT_6x8[] = {};"
+	f.SetProportional(true)
+	i := f.Index('l')
+	bearing, advance := f.Glyph(i).Bearing(), f.Glyph(i).Advance()
+
+	out, err := applyEffect(f, fxBold, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Width != 8 || out.Height != 8 || out.Count() != f.Count() || out.SourceText != "" {
+		t.Errorf("got %dx%d × %d, source %q", out.Width, out.Height, out.Count(), out.SourceText)
+	}
+	if g := out.Glyph(i); g.Bearing() != bearing || g.Advance() != advance+2 {
+		t.Errorf("'l' %d/%d, want %d/%d", g.Bearing(), g.Advance(), bearing, advance+2)
+	}
+
+	out, _ = applyEffect(f, fxOutline, 1)
+	if g := out.Glyph(i); g.Bearing() != bearing || g.Advance() != advance+2 {
+		t.Errorf("outline 'l' %d/%d, want %d/%d", g.Bearing(), g.Advance(), bearing, advance+2)
+	}
+}
+
+// Efekt, który poszerzyłby komórkę ponad 16 pikseli, jest odrzucany
+func TestApplyEffectTooWide(t *testing.T) {
+	tests := []struct {
+		w, h int
+		fx   string
+		n    int
+		ok   bool
+	}{
+		{16, 8, fxBold, 1, false},
+		{14, 8, fxBold, 2, true},
+		{14, 8, fxOutline, 1, true},
+		{15, 8, fxOutline, 1, false},
+		{12, 16, fxItalic, 4, true},
+		{12, 16, fxItalic, 3, false},
+		{15, 4, fxShadow, 2, false},
+	}
+	for _, tt := range tests {
+		f := NewFont(tt.w, tt.h, make([]uint16, tt.h))
+		out, err := applyEffect(f, tt.fx, tt.n)
+		if (err == nil) != tt.ok || (err == nil) != (out != nil) {
+			t.Errorf("%dx%d %s %d: err %v", tt.w, tt.h, tt.fx, tt.n, err)
+		}
+		if f.Width != tt.w {
+			t.Errorf("%dx%d %s %d: original resized", tt.w, tt.h, tt.fx, tt.n)
+		}
+	}
+}
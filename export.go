@@ -0,0 +1,380 @@
+/* ============================================================================
+
+    Eksport fontu
+    Generatory kodu źródłowego dla różnych języków docelowych
+    – C (uint16_t), Rust (embedded-hal), MicroPython (framebuf), Go
+
+    Wszystkie generatory pracują na tym samym Font w pamięci,
+    różnią się tylko składnią i sposobem zapisu metadanych.
+
+=========================================================================== */
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Obsługiwane formaty eksportu
+const (
+	formatC      = "C (.h)"
+	formatRust   = "Rust (.rs)"
+	formatPython = "MicroPython (.py)"
+	formatGo     = "Go (.go)"
+)
+
+// Lista formatów w kolejności wyświetlania w oknie zapisu
+var exportFormats = []string{formatC, formatRust, formatPython, formatGo}
+
+// exportOptions - ustawienia jednego zapisu fontu (okno zapisu), przekazywane
+// do generatorów
+type exportOptions struct {
+	Glyphs         []int  // wybrane glify (nil = cały font)
+	Art            bool   // komentarz z rysunkiem glifu ('#' / '.') nad każdym znakiem
+	Descriptor     bool   // struktura deskryptora za tablicą (descriptor.go)
+	DescriptorType string // nazwa typu deskryptora
+}
+
+// Ustawienia okna zapisu zapamiętane do następnego zapisu (bez wyboru glifów)
+var savePrefs = exportOptions{DescriptorType: "FontDef"}
+
+// exportExt zwraca domyślne rozszerzenie pliku dla formatu
+func exportExt(format string) string {
+	switch format {
+	case formatRust:
+		return ".rs"
+	case formatPython:
+		return ".py"
+	case formatGo:
+		return ".go"
+	}
+	return ".h"
+}
+
+// generateFontSource generuje kod źródłowy całego fontu w wybranym formacie
+func generateFontSource(f *Font, format string, opt exportOptions) string {
+	switch format {
+	case formatRust:
+		return withCodepageNote(f, generateRust(f, opt), "//")
+	case formatPython:
+		return withCodepageNote(f, generatePython(f, opt), "#")
+	case formatGo:
+		return withCodepageNote(f, generateGo(f, opt), "//")
+	}
+	return withCodepageNote(f, generateC(f, opt), "//")
+}
+
+// glyphArt rysuje glif jako wiersze znaków '#' (piksel zapalony) i '.' (zgaszony);
+// odcienie szarości i kolory RGB565 rysowane są znakami z grayRamp według jasności
+func glyphArt(g Glyph) []string {
+	f := g.Font
+	lines := make([]string, f.Height)
+	for y := range lines {
+		var sb strings.Builder
+		for x := 0; x < f.Width; x++ {
+			level := g.Level(x, y)
+			switch {
+			case f.Depth > 1 && level > 0:
+				sb.WriteByte(grayRamp[max(1, (f.Intensity(level)*(len(grayRamp)-1)+254)/255)])
+			case f.Depth > 1:
+				sb.WriteByte(grayRamp[0])
+			case level > 0:
+				sb.WriteByte('#')
+			default:
+				sb.WriteByte('.')
+			}
+		}
+		lines[y] = sb.String()
+	}
+	return lines
+}
+
+// writeGlyphArt zapisuje rysunek glifu jako komentarz blokowy /* */ lub "#" (Python)
+func writeGlyphArt(sb *strings.Builder, g Glyph, indent, comment string) {
+	lbl := g.Label()
+	if comment == "#" {
+		sb.WriteString(strings.TrimRight(indent+"# "+lbl, " ") + "\n")
+		for _, line := range glyphArt(g) {
+			sb.WriteString(indent + "# " + line + "\n")
+		}
+		return
+	}
+	sb.WriteString(strings.TrimRight(indent+"/* "+lbl, " ") + "\n")
+	for _, line := range glyphArt(g) {
+		sb.WriteString(indent + "   " + line + "\n")
+	}
+	sb.WriteString(indent + "*/\n")
+}
+
+// writeGlyphRows zapisuje wiersze glifów jako liczby hex z komentarzem znaku
+func writeGlyphRows(sb *strings.Builder, f *Font, opt exportOptions, indent, comment string) {
+	for _, i := range exportIndices(f, opt) {
+		if opt.Art {
+			writeGlyphArt(sb, f.Glyph(i), indent, "/*")
+		}
+		sb.WriteString(indent)
+		for _, row := range f.Glyph(i).Rows() {
+			sb.WriteString(fmt.Sprintf("0x%04X,", row))
+		}
+		if lbl := f.Glyph(i).Label(); lbl != "" {
+			sb.WriteString("  " + comment + " " + lbl)
+		} else {
+			sb.WriteString("  " + comment)
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// generateC generuje klasyczną tablicę const uint16_t
+func generateC(f *Font, opt exportOptions) string {
+	if f.RGB565() {
+		return generateIconC(f, opt)
+	}
+	if f.Depth > 1 {
+		return generateGrayC(f, opt)
+	}
+	var sb strings.Builder
+
+	// Nagłówek
+	sb.WriteString(fmt.Sprintf(T("generatedAuto"), versionApp))
+	sb.WriteString(T("charSize"))
+	sb.WriteString(fmt.Sprintf("%dx%d\n\n", f.Width, f.Height))
+
+	// Nazwa tablicy
+	sb.WriteString("const uint16_t " + f.BaseName() + "[] = {\n")
+	writeGlyphRows(&sb, f, opt, "   ", "//")
+	sb.WriteString("};\n")
+	writeTablesC(&sb, f, opt)
+	if opt.Descriptor {
+		sb.WriteString(generateDescriptorC(f, opt))
+	}
+
+	return sb.String()
+}
+
+// writeTablesC dopisuje tabele kodów znaków i szerokości (jeśli potrzebne)
+func writeTablesC(sb *strings.Builder, f *Font, opt exportOptions) {
+	if !exportContiguous(f, opt) {
+		sb.WriteString(fmt.Sprintf("\nconst uint16_t %s_codes[] = { %s };\n", f.BaseName(), joinInts(exportCodes(f, opt))))
+	}
+	if f.Proportional() {
+		sb.WriteString(fmt.Sprintf("\nconst uint8_t %s_widths[] = { %s };\n", f.BaseName(), joinInts(exportWidths(f, opt))))
+		sb.WriteString(fmt.Sprintf("const uint8_t %s_bearings[] = { %s };\n", f.BaseName(), joinInts(exportBearings(f, opt))))
+	}
+}
+
+// generateRust generuje statyczną tablicę [u16; N] ze stałymi opisującymi font
+func generateRust(f *Font, opt exportOptions) string {
+	if f.RGB565() {
+		return generateIconRust(f, opt)
+	}
+	if f.Depth > 1 {
+		return generateGrayRust(f, opt)
+	}
+	var sb strings.Builder
+	name := strings.ToUpper(f.BaseName())
+
+	sb.WriteString(fmt.Sprintf(T("generatedAuto"), versionApp))
+	sb.WriteString(T("charSize"))
+	sb.WriteString(fmt.Sprintf("%dx%d\n\n", f.Width, f.Height))
+
+	writeConstsRust(&sb, f, opt, name)
+	sb.WriteString("\n")
+
+	sb.WriteString("#[rustfmt::skip]\n")
+	sb.WriteString(fmt.Sprintf("pub static %s: [u16; %d] = [\n", name, len(exportIndices(f, opt))*f.Height))
+	writeGlyphRows(&sb, f, opt, "    ", "//")
+	sb.WriteString("];\n")
+	writeTablesRust(&sb, f, opt, name)
+	if opt.Descriptor {
+		sb.WriteString(generateDescriptorRust(f, opt))
+	}
+
+	return sb.String()
+}
+
+// writeConstsRust zapisuje stałe opisujące font (wymiary, pierwszy znak, liczba glifów)
+func writeConstsRust(sb *strings.Builder, f *Font, opt exportOptions, name string) {
+	sb.WriteString(fmt.Sprintf("pub const %s_WIDTH: usize = %d;\n", name, f.Width))
+	sb.WriteString(fmt.Sprintf("pub const %s_HEIGHT: usize = %d;\n", name, f.Height))
+	sb.WriteString(fmt.Sprintf("pub const %s_FIRST_CHAR: u16 = %d;\n", name, exportFirstChar(f, opt)))
+	sb.WriteString(fmt.Sprintf("pub const %s_COUNT: usize = %d;\n", name, len(exportIndices(f, opt))))
+}
+
+// writeTablesRust dopisuje tabele kodów znaków i szerokości (jeśli potrzebne)
+func writeTablesRust(sb *strings.Builder, f *Font, opt exportOptions, name string) {
+	if !exportContiguous(f, opt) {
+		sb.WriteString(fmt.Sprintf("\npub static %s_CODES: [u16; %d] = [%s];\n", name, len(exportIndices(f, opt)), joinInts(exportCodes(f, opt))))
+	}
+	if f.Proportional() {
+		sb.WriteString(fmt.Sprintf("\npub static %s_WIDTHS: [u8; %d] = [%s];\n", name, len(exportIndices(f, opt)), joinInts(exportWidths(f, opt))))
+		sb.WriteString(fmt.Sprintf("pub static %s_BEARINGS: [u8; %d] = [%s];\n", name, len(exportIndices(f, opt)), joinInts(exportBearings(f, opt))))
+	}
+}
+
+// generatePython generuje moduł MicroPython zgodny z framebuf.MONO_HLSB.
+// Każdy wiersz jest wyrównany do lewej i zapisany na pełnych bajtach (MSB first).
+func generatePython(f *Font, opt exportOptions) string {
+	var sb strings.Builder
+	bytesPerRow := (f.Width + 7) / 8
+	pad := bytesPerRow*8 - f.Width
+	if f.RGB565() {
+		bytesPerRow = 2 * f.Width
+	} else if f.Depth > 1 {
+		bytesPerRow = grayRowBytes(f)
+	}
+
+	sb.WriteString(strings.Replace(fmt.Sprintf(T("generatedAuto"), versionApp), "//", "#", 1))
+	sb.WriteString(strings.Replace(T("charSize"), "//", "#", 1))
+	if f.RGB565() {
+		sb.WriteString(fmt.Sprintf("%dx%d, RGB565\n\n", f.Width, f.Height))
+	} else if f.Depth > 1 {
+		sb.WriteString(fmt.Sprintf("%dx%d, %d bpp\n\n", f.Width, f.Height, f.Depth))
+	} else {
+		sb.WriteString(fmt.Sprintf("%dx%d\n\n", f.Width, f.Height))
+	}
+
+	sb.WriteString(fmt.Sprintf("WIDTH = %d\n", f.Width))
+	sb.WriteString(fmt.Sprintf("HEIGHT = %d\n", f.Height))
+	sb.WriteString(fmt.Sprintf("FIRST_CHAR = %d\n", exportFirstChar(f, opt)))
+	sb.WriteString(fmt.Sprintf("COUNT = %d\n", len(exportIndices(f, opt))))
+	if f.Depth > 1 {
+		sb.WriteString(fmt.Sprintf("BPP = %d\n", f.Depth))
+	}
+	if f.RGB565() {
+		sb.WriteString(fmt.Sprintf("RGB565_SWAP = %d\n", swapFlag(f)))
+	}
+	sb.WriteString(fmt.Sprintf("BYTES_PER_ROW = %d\n", bytesPerRow))
+	sb.WriteString("GLYPH_SIZE = BYTES_PER_ROW * HEIGHT\n")
+	sb.WriteString(fmt.Sprintf("BASELINE = %d\n", f.Metrics.Baseline))
+	sb.WriteString(fmt.Sprintf("ASCENT = %d\n", f.Metrics.Ascent))
+	sb.WriteString(fmt.Sprintf("DESCENT = %d\n", f.Metrics.Descent))
+	sb.WriteString(fmt.Sprintf("X_HEIGHT = %d\n", f.Metrics.XHeight))
+	sb.WriteString(fmt.Sprintf("CAP_HEIGHT = %d\n", f.Metrics.CapHeight))
+	sb.WriteString(fmt.Sprintf("LINE_SPACING = %d\n\n", f.Metrics.LineSpacing))
+
+	sb.WriteString("_DATA = (\n")
+	for _, i := range exportIndices(f, opt) {
+		if opt.Art {
+			writeGlyphArt(&sb, f.Glyph(i), "    ", "#")
+		}
+		sb.WriteString("    b'")
+		for _, b := range glyphPacked(f.Glyph(i), bytesPerRow, pad) {
+			sb.WriteString(fmt.Sprintf("\\x%02x", b))
+		}
+		sb.WriteString("'")
+		if lbl := f.Glyph(i).Label(); lbl != "" {
+			sb.WriteString("  # " + lbl)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(")\n\n")
+
+	sb.WriteString("DATA = memoryview(b''.join(_DATA))\n")
+	if !exportContiguous(f, opt) {
+		sb.WriteString(fmt.Sprintf("CODES = (%s,)\n", joinInts(exportCodes(f, opt))))
+	}
+	if f.Proportional() {
+		sb.WriteString(fmt.Sprintf("WIDTHS = (%s,)\n", joinInts(exportWidths(f, opt))))
+		sb.WriteString(fmt.Sprintf("BEARINGS = (%s,)\n", joinInts(exportBearings(f, opt))))
+	}
+	sb.WriteString("\n\n")
+	sb.WriteString("def glyph(ch):\n")
+	if exportContiguous(f, opt) {
+		sb.WriteString("    i = ord(ch) - FIRST_CHAR\n")
+		sb.WriteString("    if i < 0 or i >= COUNT:\n")
+		sb.WriteString("        i = 0\n")
+	} else {
+		sb.WriteString("    c = ord(ch)\n")
+		sb.WriteString("    i = CODES.index(c) if c in CODES else 0\n")
+	}
+	sb.WriteString("    return DATA[i * GLYPH_SIZE:(i + 1) * GLYPH_SIZE]\n")
+
+	return sb.String()
+}
+
+// glyphPacked zwraca bajty glifu dla MicroPython: MONO_HLSB, upakowane odcienie szarości
+// lub wartości RGB565
+func glyphPacked(g Glyph, bytesPerRow, pad int) []byte {
+	if g.Font.RGB565() {
+		return iconBytes(g)
+	}
+	if g.Font.Depth > 1 {
+		return packGray(g)
+	}
+	var out []byte
+	for _, r := range g.Rows() {
+		row := uint32(r) << pad
+		for b := bytesPerRow - 1; b >= 0; b-- {
+			out = append(out, byte(row>>(8*b)))
+		}
+	}
+	return out
+}
+
+// generateGo generuje plik Go z wycinkiem []uint16 i stałymi fontu
+func generateGo(f *Font, opt exportOptions) string {
+	if f.RGB565() {
+		return generateIconGo(f, opt)
+	}
+	if f.Depth > 1 {
+		return generateGrayGo(f, opt)
+	}
+	var sb strings.Builder
+	name := goFontName(f)
+
+	sb.WriteString(fmt.Sprintf(T("generatedAuto"), versionApp))
+	sb.WriteString(T("charSize"))
+	sb.WriteString(fmt.Sprintf("%dx%d\n\n", f.Width, f.Height))
+
+	sb.WriteString("package fonts\n\n")
+	writeConstsGo(&sb, f, opt, name)
+
+	sb.WriteString(fmt.Sprintf("var %s = []uint16{\n", name))
+	writeGlyphRows(&sb, f, opt, "\t", "//")
+	sb.WriteString("}\n")
+	writeTablesGo(&sb, f, opt, name)
+	if opt.Descriptor {
+		sb.WriteString(generateDescriptorGo(f, opt))
+	}
+
+	return sb.String()
+}
+
+// writeConstsGo zapisuje blok stałych opisujących font
+func writeConstsGo(sb *strings.Builder, f *Font, opt exportOptions, name string) {
+	sb.WriteString("const (\n")
+	sb.WriteString(fmt.Sprintf("\t%sWidth     = %d\n", name, f.Width))
+	sb.WriteString(fmt.Sprintf("\t%sHeight    = %d\n", name, f.Height))
+	sb.WriteString(fmt.Sprintf("\t%sFirstChar = %d\n", name, exportFirstChar(f, opt)))
+	sb.WriteString(fmt.Sprintf("\t%sCount     = %d\n", name, len(exportIndices(f, opt))))
+	if f.Depth > 1 {
+		sb.WriteString(fmt.Sprintf("\t%sBpp       = %d\n", name, f.Depth))
+	}
+	if f.RGB565() {
+		sb.WriteString(fmt.Sprintf("\t%sRGB565Swap = %t\n", name, f.Swap))
+	}
+	sb.WriteString(")\n\n")
+}
+
+// writeTablesGo dopisuje tabele kodów znaków i szerokości (jeśli potrzebne)
+func writeTablesGo(sb *strings.Builder, f *Font, opt exportOptions, name string) {
+	if !exportContiguous(f, opt) {
+		sb.WriteString(fmt.Sprintf("\nvar %sCodes = []uint16{%s}\n", name, joinInts(exportCodes(f, opt))))
+	}
+	if f.Proportional() {
+		sb.WriteString(fmt.Sprintf("\nvar %sWidths = []uint8{%s}\n", name, joinInts(exportWidths(f, opt))))
+		sb.WriteString(fmt.Sprintf("var %sBearings = []uint8{%s}\n", name, joinInts(exportBearings(f, opt))))
+	}
+}
+
+// goFontName zwraca nazwę wycinka w eksporcie Go, np. "Font8x16" (ikony RGB565: "Icons16x16")
+func goFontName(f *Font) string {
+	prefix := "Font"
+	if f.RGB565() {
+		prefix = "Icons"
+	}
+	return prefix + strconv.Itoa(f.Width) + "x" + strconv.Itoa(f.Height)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testFont tworzy font ASCII 0x20-0x7E z wbudowanego fontu bazowego
+func testFont(t *testing.T, w, h, depth int) *Font {
+	t.Helper()
+	codes, err := parseCharRange("0x20-0x7E")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := newFontFromTemplate(w, h, codes, "", base5x7, bitLayout{Depth: depth})
+	f.EstimateMetrics()
+	return f
+}
+
+// Każdy format ma nazwy i stałe w swojej konwencji, a wiersze glifu '!' (kolumna 2 fontu 5x7)
+// w swoim zapisie - uint16 z prawej w C/Rust/Go, bajty od lewej w MicroPython
+func TestGenerateFontSource(t *testing.T) {
+	f := NewFont(5, 7, []uint16{
+		0, 0, 0, 0, 0, 0, 0,
+		4, 4, 4, 4, 4, 0, 4,
+	})
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{formatC, []string{
+			"const uint16_t FONT_5x7[] = {",
+			"0x0004,0x0004,0x0004,0x0004,0x0004,0x0000,0x0004,  // '!'",
+		}},
+		{formatRust, []string{
+			"pub const FONT_5X7_WIDTH: usize = 5;",
+			"pub const FONT_5X7_FIRST_CHAR: u16 = 32;",
+			"pub const FONT_5X7_COUNT: usize = 2;",
+			"pub static FONT_5X7: [u16; 14] = [",
+			"0x0004,0x0004,0x0004,0x0004,0x0004,0x0000,0x0004,  // '!'",
+		}},
+		{formatPython, []string{
+			"WIDTH = 5\nHEIGHT = 7\nFIRST_CHAR = 32\nCOUNT = 2\nBYTES_PER_ROW = 1\n",
+			`b'\x20\x20\x20\x20\x20\x00\x20'  # '!'`,
+			"def glyph(ch):",
+		}},
+		{formatGo, []string{
+			"package fonts",
+			"Font5x7Count     = 2",
+			"var Font5x7 = []uint16{",
+			"0x0004,0x0004,0x0004,0x0004,0x0004,0x0000,0x0004,  // '!'",
+		}},
+	}
+	for _, tt := range tests {
+		src := generateFontSource(f, tt.format, exportOptions{})
+		for _, w := range tt.want {
+			if !strings.Contains(src, w) {
+				t.Errorf("%s: missing %q\n%s", tt.format, w, src)
+			}
+		}
+	}
+
+	// wygenerowany plik Go jest poprawnym kodem
+	src := generateFontSource(testFont(t, 8, 8, 1), formatGo, exportOptions{})
+	if _, err := parser.ParseFile(token.NewFileSet(), "font.go", src, parser.SkipObjectResolution); err != nil {
+		t.Errorf("Go source: %v", err)
+	}
+}
+
+func TestExportExt(t *testing.T) {
+	for format, want := range map[string]string{formatC: ".h", formatRust: ".rs", formatPython: ".py", formatGo: ".go"} {
+		if got := exportExt(format); got != want {
+			t.Errorf("exportExt(%s) = %q, want %q", format, got, want)
+		}
+	}
+}
+
+func TestGlyphArt(t *testing.T) {
+	tests := []struct {
+		name string
+		font *Font
+		want []string
+	}{
+		{"1bpp", NewFont(3, 2, []uint16{0b101, 0b010}), []string{"#.#", ".#."}},
+		{"2bpp", NewGrayFont(4, 1, 2, levelsFrom("0123")), []string{".:+#"}},
+		{"4bpp faint", NewGrayFont(3, 1, 4, levelsFrom("01?")), []string{".:#"}},
+	}
+	for _, tt := range tests {
+		if got := glyphArt(tt.font.Glyph(0)); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Rysunki glifów w komentarzach nie zmieniają wczytanego fontu
+func TestExportArtRoundTrip(t *testing.T) {
+	f := testFont(t, 8, 8, 1)
+	roundTrip(t, f, exportOptions{Art: true})
+	for _, format := range exportFormats {
+		if src := generateFontSource(f, format, exportOptions{Art: true}); !strings.Contains(src, "..#.#...") {
+			t.Errorf("%s: no art for '\"'\n%s", format, src)
+		}
+	}
+}
@@ -1,118 +1,195 @@
 /* ============================================================================
 
     Font Handling
-    Funkcje do wczytywania fontów .h oraz zapisu całej tablicy
-    – parseHeaderWithSize, saveFontDialog
+    Funkcje do wczytywania fontów .h oraz zapisu całej tablicy (typ Font - model.go)
+    – parseHeaderWithSize, saveFontDialog (wybór formatu eksportu)
 
 =========================================================================== */
 
 package main
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
-	"regexp"
-	"strconv"
+	"io"
 	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
 )
 
-// Globalne dane fontu
-var fontData []uint16
-var glyphW, glyphH int
-
-// parseHeaderWithSize odczytuje font z pliku .h i wykrywa wymiary znaków
-func parseHeaderWithSize(r fyne.URIReadCloser) ([]uint16, int, int, error) {
-	sc := bufio.NewScanner(r)
-	hexRE := regexp.MustCompile(`0x[0-9A-Fa-f]+`)
-	nameRE := regexp.MustCompile(`(?i)uint16_t\s+(\w+)`) // nazwa tablicy
-
-	var nums []uint16
-	var gw, gh int
-
-	for sc.Scan() {
-		line := sc.Text()
-
-		// Wykrycie wymiarów z nazwy tablicy np. "ALGER_16x16"
-		if gw == 0 || gh == 0 {
-			match := nameRE.FindStringSubmatch(line)
-			if len(match) > 1 {
-				name := match[1]
-				parts := strings.Split(name, "_")
-				if len(parts) > 1 {
-					sizePart := parts[len(parts)-1]
-					dims := strings.Split(sizePart, "x")
-					if len(dims) == 2 {
-						w, err1 := strconv.Atoi(dims[0])
-						h, err2 := strconv.Atoi(dims[1])
-						if err1 == nil && err2 == nil {
-							gw = w
-							gh = h
-						}
-					}
-				}
-			}
-		}
+// parseHeaderWithSize odczytuje font z pliku (.h, .rs, .py, .go) i wykrywa wymiary znaków
+func parseHeaderWithSize(r io.Reader) (*Font, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
 
-		matches := hexRE.FindAllString(line, -1)
-		for _, m := range matches {
-			v, err := strconv.ParseUint(m, 0, 16)
-			if err != nil {
-				return nil, 0, 0, err
-			}
-			nums = append(nums, uint16(v))
+	var f *Font
+	if swap, ok := iconSwapFromSource(string(src)); ok {
+		// Ikony RGB565 (znacznik zamiany bajtów zapisany przy eksporcie)
+		f, err = parseIconSource(string(src), 0, 0, swap)
+	} else if depth := bppFromSource(string(src)); depth > 1 {
+		// Tablice upakowane 2bpp / 4bpp
+		f, err = parseGraySource(string(src), depth)
+	} else {
+		var nums []uint16
+		var gw, gh int
+		nums, gw, gh, err = parseFontSource(string(src))
+		if err == nil && (gw <= 0 || gh <= 0 || gw > 16) {
+			err = errors.New(T("sizeUnknown"))
 		}
+		if err == nil {
+			f = NewFont(gw, gh, nums)
+		}
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return nums, gw, gh, sc.Err()
+	// Dane zapisane przy eksporcie obok tablicy glifów
+	f.SourceText = string(src)
+	parseMetricTables(f, f.SourceText)
+	parseCodeTable(f, f.SourceText)
+	parseMetricValues(f, f.SourceText)
+	parseCodepage(f, f.SourceText)
+	return f, nil
 }
 
 // Wywoływane przy kliknięciu "Save Font"
-func saveFontDialog(w fyne.Window) {
-	if len(fontData) == 0 {
+func saveFontDialog(w fyne.Window, f *Font) {
+	if f.Empty() {
 		dialog.ShowInformation(T("noData"), T("loadFirst"), w)
 		return
 	}
+	showSaveFontForm(w, f, "")
+}
 
-	dialog.ShowFileSave(func(uc fyne.URIWriteCloser, _ error) {
-		if uc == nil {
-			return
+// showSaveFontForm pokazuje okno zapisu fontu. Niepusty preset ustawia
+// od razu tryb "znaki użyte w tekście" (np. po skanowaniu źródeł firmware).
+func showSaveFontForm(w fyne.Window, f *Font, preset string) {
+
+	// Wybór języka docelowego
+	formatSelect := widget.NewSelect(exportFormats, nil)
+	formatSelect.SetSelected(formatC)
+
+	// Rysunek glifu w komentarzu nad każdym znakiem
+	opt := savePrefs
+	artCheck := widget.NewCheck("", func(val bool) {
+		opt.Art = val
+	})
+	artCheck.SetChecked(opt.Art)
+
+	// Struktura deskryptora z konfigurowalną nazwą typu
+	descEntry := widget.NewEntry()
+	descEntry.SetText(opt.DescriptorType)
+	descEntry.OnChanged = func(val string) {
+		opt.DescriptorType = val
+	}
+	descCheck := widget.NewCheck("", func(val bool) {
+		opt.Descriptor = val
+		if val {
+			descEntry.Enable()
+		} else {
+			descEntry.Disable()
 		}
-		defer func() { _ = uc.Close() }()
-
-		var sb strings.Builder
+	})
+	descCheck.SetChecked(opt.Descriptor)
+	if !opt.Descriptor {
+		descEntry.Disable()
+	}
 
-		// Nagłówek
-		sb.WriteString(fmt.Sprintf(T("generatedAuto"), versionApp))
-		sb.WriteString(T("charSize"))
-		sb.WriteString(fmt.Sprintf("%dx%d\n\n", glyphW, glyphH))
+	// Wybór znaków: cały font, zakres, zestaw znaków lub tekst
+	subsetEntry := widget.NewMultiLineEntry()
+	subsetEntry.SetMinRowsVisible(2)
+	subsetEntry.Disable()
+	modeNames := make([]string, len(subsetModes))
+	for i, m := range subsetModes {
+		modeNames[i] = T("subset_" + m)
+	}
+	subsetSelect := widget.NewSelect(modeNames, nil)
+	subsetSelect.OnChanged = func(val string) {
+		mode := subsetModes[indexOf(modeNames, val)]
+		if mode == subsetAll {
+			subsetEntry.Disable()
+		} else {
+			subsetEntry.Enable()
+		}
+		subsetEntry.SetPlaceHolder(T("subsetHint_" + mode))
+	}
+	if preset != "" {
+		subsetSelect.SetSelected(modeNames[indexOf(subsetModes, subsetText)])
+		subsetEntry.SetText(preset)
+	} else {
+		subsetSelect.SetSelected(modeNames[0])
+	}
 
-		// Nazwa tablicy
-		sb.WriteString("const uint16_t FONT_" + strconv.Itoa(glyphW) + "x" + strconv.Itoa(glyphH) + "[] = {\n")
+	items := []*widget.FormItem{
+		widget.NewFormItem(T("exportFormat"), formatSelect),
+		widget.NewFormItem(T("asciiArt"), artCheck),
+		widget.NewFormItem(T("descriptor"), descCheck),
+		widget.NewFormItem(T("descriptorType"), descEntry),
+		widget.NewFormItem(T("subset"), subsetSelect),
+		widget.NewFormItem("", subsetEntry),
+	}
 
-		total := len(fontData) / glyphH
-		for i := 0; i < total; i++ {
-			sb.WriteString("   ")
-			for y := 0; y < glyphH; y++ {
-				row := fontData[i*glyphH+y]
-				sb.WriteString(fmt.Sprintf("0x%04X,", row))
-			}
-			ch := i + 32
-			if ch >= 32 && ch <= 126 {
-				sb.WriteString(fmt.Sprintf("  // '%c'", rune(ch)))
-			} else {
-				sb.WriteString("  //")
+	dialog.ShowForm(T("saveFont"), T("saveAction"), T("cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		format := formatSelect.Selected
+
+		// Ustalenie glifów do zapisu
+		var codes []int
+		var err error
+		switch subsetModes[indexOf(modeNames, subsetSelect.Selected)] {
+		case subsetRange:
+			codes, err = parseCharRange(subsetEntry.Text)
+		case subsetSet, subsetText:
+			codes = charsOfText(subsetEntry.Text)
+		}
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		// ustawienia (bez wyboru glifów) zapamiętane do następnego zapisu
+		savePrefs = opt
+		var missing []rune
+		if codes != nil {
+			opt.Glyphs, missing = subsetIndices(f, codes)
+			if len(opt.Glyphs) == 0 {
+				dialog.ShowError(errors.New(T("subsetEmpty")), w)
+				return
 			}
-			sb.WriteString("\n")
 		}
 
-		sb.WriteString("};\n")
+		fd := dialog.NewFileSave(func(uc fyne.URIWriteCloser, _ error) {
+			if uc == nil {
+				return
+			}
+			defer func() { _ = uc.Close() }()
 
-		if _, err := uc.Write([]byte(sb.String())); err != nil {
-			fmt.Println(T("saveError")+": ", err)
-		}
-		dialog.ShowInformation(T("saved"), T("saved"), w)
+			if _, err := uc.Write([]byte(generateFontSource(f, format, opt))); err != nil {
+				fmt.Println(T("saveError")+": ", err)
+			}
+			msg := T("saved")
+			if opt.Glyphs != nil {
+				msg = subsetReport(f, opt, missing)
+			}
+			dialog.ShowInformation(T("saved"), msg, w)
+		}, w)
+		fd.SetFileName(strings.ToLower(f.BaseName()) + exportExt(format))
+		fd.Show()
 	}, w)
 }
+
+// indexOf zwraca pozycję wartości na liście (0, jeśli brak)
+func indexOf(list []string, val string) int {
+	for i, v := range list {
+		if v == val {
+			return i
+		}
+	}
+	return 0
+}
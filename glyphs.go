@@ -0,0 +1,200 @@
+/* ============================================================================
+
+    Lista glifów
+    Wstawianie pustych glifów, duplikowanie, usuwanie i przesuwanie glifów
+    oraz zmiana kodu znaku pojedynczego glifu
+    – Font.InsertGlyph / DuplicateGlyph / DeleteGlyph / MoveGlyph,
+      Font.SetCode, parseCodeTable
+
+    Kody znaków: Font.Codes = nil oznacza kolejne kody od FirstChar.
+    Tryb "kolejne kody" (renumber) po każdej operacji nadaje glifom
+    kody od nowa – glify przesuwają się na sąsiednie znaki. Bez niego
+    każdy glif zachowuje swój kod, a font może dostać jawną tabelę
+    kodów (zapisywaną w eksporcie jako codes[]).
+
+=========================================================================== */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// Tabela kodów w źródłach: FONT_8x16_codes[], FONT_8X16_CODES, Font8x16Codes, CODES
+var codeTableRE = regexp.MustCompile(`(?i)\b\w*?codes\b[^=\n,]*=\s*(?:&\s*)?(?:\[\]\s*uint16\s*)?[\[\({]`)
+
+// ensureCodes zapisuje kody wszystkich glifów jawnie (przed zmianą listy glifów)
+func (f *Font) ensureCodes() {
+	if f.Codes != nil {
+		return
+	}
+	f.Codes = make([]int, f.Count())
+	for i := range f.Codes {
+		f.Codes[i] = f.FirstChar + i
+	}
+}
+
+// compactCodes wraca do zapisu FirstChar + indeks, jeśli kody są kolejne
+func (f *Font) compactCodes() {
+	if len(f.Codes) == 0 {
+		f.Codes = nil
+		return
+	}
+	for i, c := range f.Codes {
+		if c != f.Codes[0]+i {
+			return
+		}
+	}
+	f.FirstChar = f.Codes[0]
+	f.Codes = nil
+}
+
+// editGlyphs wykonuje operację na liście glifów: z zachowaniem kodów glifów
+// lub z nadaniem kolejnych kodów od FirstChar (renumber)
+func (f *Font) editGlyphs(renumber bool, op func()) {
+	if !renumber {
+		f.ensureCodes()
+	}
+	op()
+	if renumber {
+		f.Codes = nil
+	} else {
+		f.compactCodes()
+	}
+}
+
+// freeCode zwraca pierwszy nieużywany kod większy od podanego
+func (f *Font) freeCode(after int) int {
+	code := after + 1
+	for f.Index(code) >= 0 {
+		code++
+	}
+	return code
+}
+
+// insertGlyph wstawia glif o podanych wierszach i poziomach na pozycję at
+func (f *Font) insertGlyph(at, code int, rows, levels []uint16) {
+	if f.Codes != nil {
+		f.Codes = slices.Insert(f.Codes, at, code)
+	}
+	if f.Pixels != nil {
+		if levels == nil {
+			levels = make([]uint16, f.Width*f.Height)
+		}
+		f.Pixels = slices.Insert(f.Pixels, at*f.Width*f.Height, levels...)
+	}
+	if f.Proportional() {
+		f.Advance = slices.Insert(f.Advance, at, f.Width)
+		f.Bearing = slices.Insert(f.Bearing, at, 0)
+	}
+	f.Data = slices.Insert(f.Data, at*f.Height, rows...)
+}
+
+// deleteGlyph usuwa glif z pozycji i
+func (f *Font) deleteGlyph(i int) {
+	if f.Codes != nil {
+		f.Codes = slices.Delete(f.Codes, i, i+1)
+	}
+	if f.Pixels != nil {
+		n := f.Width * f.Height
+		f.Pixels = slices.Delete(f.Pixels, i*n, (i+1)*n)
+	}
+	if f.Proportional() {
+		f.Advance = slices.Delete(f.Advance, i, i+1)
+		f.Bearing = slices.Delete(f.Bearing, i, i+1)
+	}
+	f.Data = slices.Delete(f.Data, i*f.Height, (i+1)*f.Height)
+}
+
+// copyGlyph wstawia kopię glifu src na pozycję at z podanym kodem
+func (f *Font) copyGlyph(src, at, code int) {
+	g := f.Glyph(src)
+	bearing, advance := g.Bearing(), g.Advance()
+	f.insertGlyph(at, code, slices.Clone(g.Rows()), levelsOf(g))
+	if f.Proportional() {
+		f.Glyph(at).SetMetrics(bearing, advance)
+	}
+}
+
+// InsertGlyph wstawia pusty glif na pozycję at (kod: pierwszy wolny po poprzednim glifie)
+func (f *Font) InsertGlyph(at int, renumber bool) {
+	f.editGlyphs(renumber, func() {
+		code := f.FirstChar - 1
+		if at > 0 {
+			code = f.Code(at - 1)
+		}
+		f.insertGlyph(at, f.freeCode(code), make([]uint16, f.Height), nil)
+		if f.Proportional() {
+			f.Glyph(at).AutoWidth()
+		}
+	})
+}
+
+// DuplicateGlyph wstawia kopię glifu i zaraz za nim
+func (f *Font) DuplicateGlyph(i int, renumber bool) {
+	f.editGlyphs(renumber, func() {
+		f.copyGlyph(i, i+1, f.freeCode(f.Code(i)))
+	})
+}
+
+// DeleteGlyph usuwa glif i (ostatniego glifu fontu nie można usunąć)
+func (f *Font) DeleteGlyph(i int, renumber bool) error {
+	if f.Count() <= 1 {
+		return errors.New(T("glyphLastDelete"))
+	}
+	f.editGlyphs(renumber, func() {
+		f.deleteGlyph(i)
+	})
+	return nil
+}
+
+// MoveGlyph przenosi glif z pozycji from na pozycję to
+func (f *Font) MoveGlyph(from, to int, renumber bool) {
+	if from == to || to < 0 || to >= f.Count() {
+		return
+	}
+	f.editGlyphs(renumber, func() {
+		code := f.Code(from)
+		// kopia na miejscu docelowym, potem usunięcie oryginału
+		if to > from {
+			f.copyGlyph(from, to+1, code)
+			f.deleteGlyph(from)
+		} else {
+			f.copyGlyph(from, to, code)
+			f.deleteGlyph(from + 1)
+		}
+	})
+}
+
+// SetCode zmienia kod znaku glifu i (kod nie może być użyty przez inny glif)
+func (f *Font) SetCode(i, code int) error {
+	if j := f.Index(code); j >= 0 && j != i {
+		return fmt.Errorf(T("codeUsed"), code, j)
+	}
+	f.ensureCodes()
+	f.Codes[i] = code
+	f.compactCodes()
+	return nil
+}
+
+// parseCodeTable odczytuje tabelę kodów znaków zapisaną przy eksporcie podzbioru
+// lub fontu z jawnymi kodami, jeśli liczba wpisów zgadza się z liczbą glifów
+func parseCodeTable(f *Font, src string) {
+	m := codeTableRE.FindStringIndex(src)
+	if m == nil {
+		return
+	}
+	open := m[1] - 1
+	values, _, err := parseInitializer(initializerBody(src, open, src[open] == '('))
+	if err != nil || len(values) != f.Count() {
+		return
+	}
+	f.Codes = make([]int, len(values))
+	for i, v := range values {
+		f.Codes[i] = int(v)
+	}
+	f.compactCodes()
+}
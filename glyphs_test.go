@@ -0,0 +1,138 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// glyphsFont tworzy font 3x1 z glifami 'A'..'D' (wiersz = numer glifu + 1)
+func glyphsFont() *Font {
+	f := NewFont(3, 1, []uint16{1, 2, 3, 4})
+	f.FirstChar = 'A'
+	return f
+}
+
+// fontCodes zwraca kody wszystkich glifów fontu
+func fontCodes(f *Font) []int {
+	codes := make([]int, f.Count())
+	for i := range codes {
+		codes[i] = f.Code(i)
+	}
+	return codes
+}
+
+func TestGlyphListEdits(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(f *Font)
+		rows     []uint16
+		codes    []int
+		explicit bool // font dostaje jawną tabelę kodów
+	}{
+		{"insert keeps codes", func(f *Font) { f.InsertGlyph(1, false) },
+			[]uint16{1, 0, 2, 3, 4}, []int{'A', 'E', 'B', 'C', 'D'}, true},
+		{"insert renumbers", func(f *Font) { f.InsertGlyph(1, true) },
+			[]uint16{1, 0, 2, 3, 4}, []int{'A', 'B', 'C', 'D', 'E'}, false},
+		{"insert at end", func(f *Font) { f.InsertGlyph(4, false) },
+			[]uint16{1, 2, 3, 4, 0}, []int{'A', 'B', 'C', 'D', 'E'}, false},
+		{"duplicate", func(f *Font) { f.DuplicateGlyph(0, false) },
+			[]uint16{1, 1, 2, 3, 4}, []int{'A', 'E', 'B', 'C', 'D'}, true},
+		{"delete keeps codes", func(f *Font) { _ = f.DeleteGlyph(1, false) },
+			[]uint16{1, 3, 4}, []int{'A', 'C', 'D'}, true},
+		{"delete renumbers", func(f *Font) { _ = f.DeleteGlyph(1, true) },
+			[]uint16{1, 3, 4}, []int{'A', 'B', 'C'}, false},
+		{"delete first stays contiguous", func(f *Font) { _ = f.DeleteGlyph(0, false) },
+			[]uint16{2, 3, 4}, []int{'B', 'C', 'D'}, false},
+		{"move forward keeps codes", func(f *Font) { f.MoveGlyph(0, 2, false) },
+			[]uint16{2, 3, 1, 4}, []int{'B', 'C', 'A', 'D'}, true},
+		{"move back renumbers", func(f *Font) { f.MoveGlyph(3, 0, true) },
+			[]uint16{4, 1, 2, 3}, []int{'A', 'B', 'C', 'D'}, false},
+		{"move out of range ignored", func(f *Font) { f.MoveGlyph(0, 4, false) },
+			[]uint16{1, 2, 3, 4}, []int{'A', 'B', 'C', 'D'}, false},
+		{"set code", func(f *Font) { _ = f.SetCode(3, 'Z') },
+			[]uint16{1, 2, 3, 4}, []int{'A', 'B', 'C', 'Z'}, true},
+		{"set code back to contiguous", func(f *Font) { _ = f.SetCode(3, 'Z'); _ = f.SetCode(3, 'D') },
+			[]uint16{1, 2, 3, 4}, []int{'A', 'B', 'C', 'D'}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := glyphsFont()
+			tt.op(f)
+			if !slices.Equal(f.Data, tt.rows) {
+				t.Errorf("rows %v, want %v", f.Data, tt.rows)
+			}
+			if got := fontCodes(f); !slices.Equal(got, tt.codes) {
+				t.Errorf("codes %q, want %q", got, tt.codes)
+			}
+			if (f.Codes != nil) != tt.explicit {
+				t.Errorf("explicit codes %v, want %t", f.Codes, tt.explicit)
+			}
+		})
+	}
+}
+
+// Operacje na liście przesuwają też poziomy pikseli i szerokości glifów
+func TestGlyphListEditsGrayProportional(t *testing.T) {
+	f := NewGrayFont(2, 1, 2, []uint16{1, 0, 2, 0, 3, 0})
+	f.Glyph(0).SetMetrics(0, 1)
+	f.Glyph(1).SetMetrics(1, 1)
+	f.Glyph(2).SetMetrics(0, 2)
+
+	f.MoveGlyph(2, 0, false)
+	f.DuplicateGlyph(1, false)
+	if err := f.DeleteGlyph(3, false); err != nil {
+		t.Fatal(err)
+	}
+	wantPixels := []uint16{3, 0, 1, 0, 1, 0}
+	if !slices.Equal(f.Pixels, wantPixels) {
+		t.Errorf("pixels %v, want %v", f.Pixels, wantPixels)
+	}
+	if !slices.Equal(f.Bearing, []int{0, 0, 0}) || !slices.Equal(f.Advance, []int{2, 1, 1}) {
+		t.Errorf("bearings %v advances %v", f.Bearing, f.Advance)
+	}
+}
+
+func TestGlyphListErrors(t *testing.T) {
+	f := glyphsFont()
+	if err := f.SetCode(0, 'B'); err == nil {
+		t.Error("SetCode to a used code: expected error")
+	}
+	one := NewFont(3, 1, []uint16{1})
+	if err := one.DeleteGlyph(0, false); err == nil || one.Count() != 1 {
+		t.Error("deleting the last glyph: expected error")
+	}
+}
+
+func TestParseCodeTable(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []int
+	}{
+		{"c", "const uint16_t FONT_3x1_codes[] = { 65, 0x43, 90 };", []int{'A', 'C', 'Z'}},
+		{"rust", "pub static FONT_3X1_CODES: [u16; 3] = [65, 67, 90];", []int{'A', 'C', 'Z'}},
+		{"python", "CODES = (65, 67, 90,)", []int{'A', 'C', 'Z'}},
+		{"go", "var Font3x1Codes = []uint16{65, 67, 90}", []int{'A', 'C', 'Z'}},
+		{"count mismatch ignored", "CODES = (65, 67)", []int{'A', 'B', 'C'}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFont(3, 1, []uint16{1, 2, 3})
+			f.FirstChar = 'A'
+			parseCodeTable(f, tt.src)
+			if got := fontCodes(f); !slices.Equal(got, tt.want) {
+				t.Errorf("codes %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Font z lukami w kodach (usunięte glify) wraca z pliku z tą samą tabelą kodów
+func TestSparseCodesRoundTrip(t *testing.T) {
+	f := testFont(t, 8, 8, 1)
+	f.DeleteGlyph(f.Index('B'), false)
+	f.DeleteGlyph(f.Index('x'), false)
+	for _, opt := range []exportOptions{{}, {Descriptor: true}} {
+		roundTrip(t, f, opt)
+	}
+}
@@ -0,0 +1,148 @@
+/* ============================================================================
+
+    Glif jako tekst w schowku systemowym
+    Kopiowanie glifu jako rysunku ASCII lub linii hex C (jak w podglądzie
+    zapisu edytora) i wklejanie obu formatów z powrotem do glifu
+    – glyphHexLine, glyphArtText, parseGlyphText
+
+    Tekst z liczbami 0x.. poza komentarzami czytany jest jak linia hex:
+    wiersze uint16 (1bpp), upakowane bajty (odcienie szarości) lub
+    wartości RGB565. Pozostały tekst to rysunek:
+    wiersze złożone ze znaków '.', '-', '_' (tło) i ':', '+', '#', 'X',
+    '*', '@', 'O' (tusz), wstawiany od lewego górnego rogu komórki.
+
+=========================================================================== */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Znaki rysunku ASCII rozpoznawane przy wklejaniu (poza grayRamp)
+const (
+	artBlank = "-_"
+	artInk   = "X*@O"
+)
+
+// Liczba szesnastkowa linii hex (poza komentarzami - etykieta "','" to nie hex)
+var hexTokenRE = regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b`)
+
+// glyphHexLine zwraca glif jako linię hex C z komentarzem znaku
+// (wartości jak w eksporcie: wiersze, upakowane bajty lub RGB565)
+func glyphHexLine(g Glyph) string {
+	f := g.Font
+	var values []string
+	switch {
+	case f.RGB565():
+		// ikony RGB565 - wartości jak w eksporcie (z zamianą bajtów)
+		for _, v := range exportPixels(g) {
+			values = append(values, fmt.Sprintf("0x%04X", v))
+		}
+	case f.Depth > 1:
+		// upakowane odcienie szarości - bajty jak w eksporcie
+		for _, b := range packGray(g) {
+			values = append(values, fmt.Sprintf("0x%02X", b))
+		}
+	default:
+		for _, row := range g.Rows() {
+			values = append(values, fmt.Sprintf("0x%04X", row))
+		}
+	}
+	return strings.Join(values, ",") + fmt.Sprintf(", // '%c'", f.Rune(g.Code()))
+}
+
+// glyphArtText zwraca glif jako rysunek ASCII w komentarzu C
+func glyphArtText(g Glyph) string {
+	var sb strings.Builder
+	writeGlyphArt(&sb, g, "", "/*")
+	return sb.String()
+}
+
+// parseGlyphText odczytuje glif z tekstu schowka (linia hex lub rysunek ASCII)
+// i zwraca poziomy pikseli całej komórki fontu f
+func parseGlyphText(f *Font, text string) ([]uint16, error) {
+	if hexTokenRE.MatchString(initializerBody(text, 0, false)) {
+		return parseGlyphHex(f, text)
+	}
+	return parseGlyphArt(f, text)
+}
+
+// parseGlyphHex odczytuje linię hex w formacie podglądu zapisu edytora
+func parseGlyphHex(f *Font, text string) ([]uint16, error) {
+	values, _, err := parseInitializer(initializerBody(text, 0, false))
+	if err != nil {
+		return nil, err
+	}
+	n := f.Width * f.Height
+	switch {
+	case f.RGB565():
+		if len(values) != n {
+			return nil, fmt.Errorf(T("glyphTextCount"), len(values), n)
+		}
+		levels := make([]uint16, n)
+		for i, v := range values {
+			levels[i] = uint16(v)
+			if f.Swap {
+				levels[i] = swap16(levels[i])
+			}
+		}
+		return levels, nil
+	case f.Depth > 1:
+		if len(values) != grayRowBytes(f)*f.Height {
+			return nil, fmt.Errorf(T("glyphTextCount"), len(values), grayRowBytes(f)*f.Height)
+		}
+		return unpackGray(values, f.Width, f.Height, f.Depth)
+	}
+	if len(values) != f.Height {
+		return nil, fmt.Errorf(T("glyphTextCount"), len(values), f.Height)
+	}
+	levels := make([]uint16, n)
+	for y, v := range values {
+		for x := 0; x < f.Width; x++ {
+			levels[y*f.Width+x] = uint16(v>>(f.Width-1-x)) & 1
+		}
+	}
+	return levels, nil
+}
+
+// parseGlyphArt odczytuje rysunek ASCII; wiersze z innymi znakami (np. "/* 'A'")
+// są pomijane, prefiks komentarza Pythona "# " jest usuwany
+func parseGlyphArt(f *Font, text string) ([]uint16, error) {
+	levels := make([]uint16, f.Width*f.Height)
+	y := 0
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if rest, ok := strings.CutPrefix(line, "# "); ok {
+			line = strings.TrimSpace(rest)
+		}
+		if line == "" || strings.Trim(line, grayRamp+artBlank+artInk) != "" {
+			continue
+		}
+		if y < f.Height {
+			for x, c := range line[:min(len(line), f.Width)] {
+				levels[y*f.Width+x] = uint16(artLevel(f, c))
+			}
+		}
+		y++
+	}
+	if y == 0 {
+		return nil, errors.New(T("glyphTextNone"))
+	}
+	return levels, nil
+}
+
+// artLevel zamienia znak rysunku na poziom piksela fontu f
+func artLevel(f *Font, c rune) int {
+	i := strings.IndexRune(grayRamp, c)
+	switch {
+	case i == 0 || strings.ContainsRune(artBlank, c):
+		return 0
+	case i < 0:
+		return f.LevelOf(255)
+	}
+	return max(1, f.LevelOf(i*255/(len(grayRamp)-1)))
+}
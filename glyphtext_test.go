@@ -0,0 +1,110 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// Tekst ze schowka: hex rozpoznawany po liczbach 0x poza komentarzami, inaczej rysunek
+func TestParseGlyphText(t *testing.T) {
+	f := NewFont(3, 2, make([]uint16, 2))
+	tests := []struct {
+		name string
+		text string
+		want string // poziomy pikseli jak w levelsFrom
+	}{
+		{"hex line", "0x0005,0x0002, // 'A'", "101010"},
+		{"hex with comma label", "0x0001,0x0004, // ','", "001100"},
+		{"hex decimal mix", "{ 0x7, 0 }", "111000"},
+		{"art", "#.#\n.#.", "101010"},
+		{"art in comment", "/* 'A'\n   .#.\n   #.#\n*/", "010101"},
+		{"art with hex in label", "/* 0x41 */\n##.\n..#", "110001"},
+		{"python art", "# 'A'\n# X--\n# _@*", "100011"},
+		{"art wider and taller", "####\n#..#\n####", "111100"},
+		{"art shorter", ".#", "010000"},
+	}
+	for _, tt := range tests {
+		got, err := parseGlyphText(f, tt.text)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if want := levelsFrom(tt.want); !slices.Equal(got, want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, want)
+		}
+	}
+}
+
+func TestParseGlyphTextErrors(t *testing.T) {
+	f := NewFont(3, 2, make([]uint16, 2))
+	gray := NewGrayFont(3, 2, 2, make([]uint16, 6))
+	tests := []struct {
+		name string
+		f    *Font
+		text string
+	}{
+		{"too few rows", f, "0x0005, // 'A'"},
+		{"too many rows", f, "0x1,0x2,0x3"},
+		{"gray byte count", gray, "0x00,0x00,0x00"},
+		{"no art", f, "hello\nworld"},
+		{"empty", f, ""},
+	}
+	for _, tt := range tests {
+		if _, err := parseGlyphText(tt.f, tt.text); err == nil {
+			t.Errorf("%s: accepted", tt.name)
+		}
+	}
+}
+
+// Linia hex i rysunek kopiowane z glifu wklejają się z powrotem do tego samego fontu
+// (rysunek ma cztery jasności grayRamp - 4bpp i RGB565 sprawdzane tylko przez hex)
+func TestGlyphTextRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		f    *Font
+		art  bool
+	}{
+		{"1bpp", NewFont(5, 2, []uint16{0b10011, 0b01100}), true},
+		{"2bpp", NewGrayFont(5, 2, 2, levelsFrom("01230", "32100")), true},
+		{"4bpp", NewGrayFont(3, 1, 4, levelsFrom("0?8")), false},
+		{"RGB565 swapped", NewIconFont(2, 1, []uint16{0x1234, 0xF800}, true), false},
+	}
+	for _, tt := range tests {
+		want := tt.f.Glyph(0).Levels()
+		got, err := parseGlyphText(tt.f, glyphHexLine(tt.f.Glyph(0)))
+		if err != nil || !slices.Equal(got, want) {
+			t.Errorf("%s hex: got %v, %v; want %v", tt.name, got, err, want)
+		}
+		if !tt.art {
+			continue
+		}
+		got, err = parseGlyphText(tt.f, glyphArtText(tt.f.Glyph(0)))
+		if err != nil || !slices.Equal(got, want) {
+			t.Errorf("%s art: got %v, %v; want %v", tt.name, got, err, want)
+		}
+	}
+}
+
+// Rysunek wklejany do fontu w odcieniach szarości: znaki grayRamp to kolejne
+// jasności, znaki tuszu spoza rampy - pełny tusz
+func TestArtLevel(t *testing.T) {
+	tests := []struct {
+		depth int
+		art   string
+		want  []uint16
+	}{
+		{1, ".:+#X-", []uint16{0, 1, 1, 1, 1, 0}},
+		{2, ".:+#@_", []uint16{0, 1, 2, 3, 3, 0}},
+		{4, ".:+#*", []uint16{0, 5, 10, 15, 15}},
+	}
+	for _, tt := range tests {
+		f := NewGrayFont(len(tt.art), 1, tt.depth, make([]uint16, len(tt.art)))
+		if tt.depth == 1 {
+			f = NewFont(len(tt.art), 1, []uint16{0})
+		}
+		got, err := parseGlyphArt(f, tt.art)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("%d bpp: got %v, %v; want %v", tt.depth, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,387 @@
+/* ============================================================================
+
+    Glify w odcieniach szarości (2bpp / 4bpp)
+    Fonty z wygładzaniem krawędzi dla kolorowych wyświetlaczy TFT
+    – Glyph.Level / SetLevel, Font.SetDepth, packGray / unpackGray,
+      generatory C / Rust / MicroPython / Go dla tablic upakowanych,
+      parseGraySource
+
+    Poziomy jasności trzymane są w Font.Pixels (jeden uint16 na piksel,
+    0 = tło, MaxLevel = pełny tusz). Font.Data zawiera wtedy maskę tuszu
+    (piksel zapalony, jeśli poziom > 0) – dzięki temu szerokości, metryki
+    i podgląd napisu działają bez zmian.
+
+    Układ w eksporcie: piksele od lewej, MSB first, Depth bitów na piksel,
+    każdy wiersz dopełniony do pełnego bajtu ((Width*Depth+7)/8 bajtów).
+
+=========================================================================== */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Obsługiwane głębie bitowe (bity na piksel; 16 = ikony RGB565, icon.go)
+var depths = []int{1, 2, 4, depthRGB565}
+
+// Deklaracja głębi w źródle: "#define FONT_8x16_BPP 4", "BPP = 4", "Font8x16Bpp = 4"
+var bppRE = regexp.MustCompile(`(?i)\b\w*bpp\b\s*(?::\s*\w+\s*)?=?\s*(\d+)`)
+
+// Tablica bajtów C: "const uint8_t FONT_8x16[] = {"
+var cByteDeclRE = regexp.MustCompile(`(?:uint8_t|unsigned\s+char)\s+(\w+)\s*\[[^\]]*\]\s*=\s*\{`)
+
+// Znaki rysunku ASCII dla kolejnych poziomów jasności
+const grayRamp = ".:+#"
+
+// MaxLevel zwraca najwyższy poziom jasności piksela (RGB565: największa wartość koloru)
+func (f *Font) MaxLevel() int {
+	if f.Depth <= 1 {
+		return 1
+	}
+	return 1<<f.Depth - 1
+}
+
+// NewGrayFont tworzy font w odcieniach szarości z poziomów pikseli (Width*Height na glif)
+func NewGrayFont(w, h, depth int, levels []uint16) *Font {
+	f := &Font{Width: w, Height: h, FirstChar: defaultFirstChar, Depth: depth, Pixels: levels}
+	f.Data = make([]uint16, len(levels)/w)
+	for i := range f.Data {
+		f.syncMask(i)
+	}
+	f.EstimateMetrics()
+	return f
+}
+
+// depthOptions zwraca nazwy głębi do listy wyboru ("1 bpp", ..., "RGB565")
+func depthOptions() []string {
+	var names []string
+	for _, d := range depths {
+		if d == depthRGB565 {
+			names = append(names, "RGB565")
+		} else {
+			names = append(names, strconv.Itoa(d)+" bpp")
+		}
+	}
+	return names
+}
+
+// Grayscale zwraca true dla fontu w odcieniach szarości (upakowane 2bpp / 4bpp)
+func (f *Font) Grayscale() bool {
+	return f.Depth > 1 && !f.RGB565()
+}
+
+// syncMask odtwarza wiersz maski tuszu (Font.Data) z poziomów jasności
+func (f *Font) syncMask(row int) {
+	var bits uint16
+	for x := 0; x < f.Width; x++ {
+		bits <<= 1
+		if f.Pixels[row*f.Width+x] > 0 {
+			bits |= 1
+		}
+	}
+	f.Data[row] = bits
+}
+
+// SetDepth zmienia głębię bitową fontu, przeliczając piksele przez jasność 0..255
+// (1 bpp: próg w połowie skali, RGB565: odcienie szarości)
+func (f *Font) SetDepth(depth int) {
+	depth = max(depth, 1)
+	if depth == max(f.Depth, 1) {
+		return
+	}
+	f.Swap = f.Swap && depth == depthRGB565
+	intensity := make([]int, len(f.Data)*f.Width)
+	for i := range intensity {
+		row, x := i/f.Width, i%f.Width
+		intensity[i] = f.Intensity(f.Glyph(row/f.Height).Level(x, row%f.Height))
+	}
+
+	if depth == 1 {
+		for row := range f.Data {
+			var bits uint16
+			for x := 0; x < f.Width; x++ {
+				bits <<= 1
+				if intensity[row*f.Width+x]*2 >= 255 {
+					bits |= 1
+				}
+			}
+			f.Data[row] = bits
+		}
+		f.Depth, f.Pixels = 1, nil
+		return
+	}
+
+	f.Depth = depth
+	f.Pixels = make([]uint16, len(intensity))
+	for i, v := range intensity {
+		f.Pixels[i] = uint16(f.LevelOf(v))
+	}
+	for row := range f.Data {
+		f.syncMask(row)
+	}
+}
+
+// LevelOf zwraca poziom piksela o jasności tuszu 0..255 (odwrotność Intensity;
+// 1 bpp: próg w połowie skali, RGB565: odcień szarości)
+func (f *Font) LevelOf(v int) int {
+	switch {
+	case f.Depth == depthRGB565:
+		return int(grayRGB565(v))
+	case f.Depth <= 1:
+		if v*2 >= 255 {
+			return 1
+		}
+		return 0
+	}
+	return (v*f.MaxLevel() + 127) / 255
+}
+
+// Intensity zwraca jasność tuszu piksela 0..255 (0 = tło)
+func (f *Font) Intensity(level int) int {
+	if f.Depth == depthRGB565 {
+		return luminance565(uint16(level))
+	}
+	return level * 255 / f.MaxLevel()
+}
+
+// Level zwraca poziom jasności piksela (x, y); poza komórką 0
+func (g Glyph) Level(x, y int) int {
+	f := g.Font
+	if f.Depth <= 1 {
+		if g.Pixel(x, y) {
+			return 1
+		}
+		return 0
+	}
+	if x < 0 || y < 0 || x >= f.Width || y >= f.Height {
+		return 0
+	}
+	return int(f.Pixels[(g.Index*f.Height+y)*f.Width+x])
+}
+
+// SetLevel ustawia poziom jasności piksela (x, y)
+func (g Glyph) SetLevel(x, y, level int) {
+	f := g.Font
+	if f.Depth <= 1 {
+		g.SetPixel(x, y, level > 0)
+		return
+	}
+	if x < 0 || y < 0 || x >= f.Width || y >= f.Height {
+		return
+	}
+	row := g.Index*f.Height + y
+	f.Pixels[row*f.Width+x] = uint16(max(0, min(level, f.MaxLevel())))
+	f.syncMask(row)
+}
+
+// Levels zwraca kopię poziomów wszystkich pikseli glifu (Width*Height, wierszami)
+func (g Glyph) Levels() []uint16 {
+	f := g.Font
+	out := make([]uint16, f.Width*f.Height)
+	for y := 0; y < f.Height; y++ {
+		for x := 0; x < f.Width; x++ {
+			out[y*f.Width+x] = uint16(g.Level(x, y))
+		}
+	}
+	return out
+}
+
+// SetLevels zapisuje poziomy wszystkich pikseli glifu
+func (g Glyph) SetLevels(levels []uint16) {
+	f := g.Font
+	for y := 0; y < f.Height; y++ {
+		for x := 0; x < f.Width; x++ {
+			g.SetLevel(x, y, int(levels[y*f.Width+x]))
+		}
+	}
+}
+
+// PixelColor zamienia wartość piksela na kolor podglądu
+// (odcienie: 0 = białe tło, RGB565: kolor zapisany w pikselu)
+func (f *Font) PixelColor(level int) color.Color {
+	if f.Depth == depthRGB565 {
+		return rgb565Color(uint16(level))
+	}
+	return color.Gray{Y: uint8(255 - f.Intensity(level))}
+}
+
+// grayRowBytes zwraca liczbę bajtów upakowanego wiersza
+func grayRowBytes(f *Font) int {
+	return (f.Width*f.Depth + 7) / 8
+}
+
+// packGray pakuje poziomy glifu: Depth bitów na piksel, MSB first, wiersze do pełnego bajtu
+func packGray(g Glyph) []byte {
+	f := g.Font
+	rowBytes := grayRowBytes(f)
+	out := make([]byte, rowBytes*f.Height)
+	for y := 0; y < f.Height; y++ {
+		for x := 0; x < f.Width; x++ {
+			bit := x * f.Depth
+			shift := 8 - f.Depth - bit%8
+			out[y*rowBytes+bit/8] |= byte(g.Level(x, y) << shift)
+		}
+	}
+	return out
+}
+
+// unpackGray rozpakowuje bajty na poziomy pikseli (Width*Height na glif)
+func unpackGray(values []uint32, w, h, depth int) ([]uint16, error) {
+	rowBytes := (w*depth + 7) / 8
+	if len(values) == 0 || len(values)%(rowBytes*h) != 0 {
+		return nil, fmt.Errorf(T("grayBadSize"), len(values), rowBytes*h)
+	}
+	rows := len(values) / rowBytes
+	levels := make([]uint16, rows*w)
+	mask := uint32(1<<depth - 1)
+	for r := 0; r < rows; r++ {
+		for x := 0; x < w; x++ {
+			bit := x * depth
+			v := values[r*rowBytes+bit/8]
+			levels[r*w+x] = uint16((v >> (8 - depth - bit%8)) & mask)
+		}
+	}
+	return levels, nil
+}
+
+// bppFromSource zwraca głębię zapisaną w źródle (1, jeśli brak lub nieobsługiwana)
+func bppFromSource(src string) int {
+	if m := bppRE.FindStringSubmatch(src); m != nil {
+		if v, _ := strconv.Atoi(m[1]); v == 2 || v == 4 {
+			return v
+		}
+	}
+	return 1
+}
+
+// parseGraySource odczytuje upakowaną tablicę 2bpp / 4bpp (C, Rust, Python lub Go)
+func parseGraySource(src string, depth int) (*Font, error) {
+	var name, body string
+	if m := cByteDeclRE.FindStringSubmatchIndex(src); m != nil {
+		name = src[m[2]:m[3]]
+		body = initializerBody(src, m[1]-1, false)
+	} else if n, _, b, ok := findArray(src); ok {
+		name, body = n, b
+	} else {
+		return nil, errors.New(T("grayNoArray"))
+	}
+
+	gw, gh := sizeFromName(name)
+	if gw == 0 || gh == 0 {
+		gw, gh = sizeFromMeta(src)
+	}
+	if gw <= 0 || gh <= 0 || gw > 16 {
+		return nil, errors.New(T("sizeUnknown"))
+	}
+
+	values, _, err := parseInitializer(body)
+	if err != nil {
+		return nil, err
+	}
+	levels, err := unpackGray(values, gw, gh, depth)
+	if err != nil {
+		return nil, err
+	}
+	return NewGrayFont(gw, gh, depth, levels), nil
+}
+
+// writeGrayRows zapisuje upakowane bajty glifów, jeden glif w linii
+func writeGrayRows(sb *strings.Builder, f *Font, opt exportOptions, indent, comment string) {
+	for _, i := range exportIndices(f, opt) {
+		g := f.Glyph(i)
+		if opt.Art {
+			writeGlyphArt(sb, g, indent, "/*")
+		}
+		sb.WriteString(indent)
+		for _, b := range packGray(g) {
+			sb.WriteString(fmt.Sprintf("0x%02X,", b))
+		}
+		if lbl := g.Label(); lbl != "" {
+			sb.WriteString("  " + comment + " " + lbl)
+		} else {
+			sb.WriteString("  " + comment)
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// glyphBytes zwraca liczbę bajtów jednego glifu w eksporcie
+func glyphBytes(f *Font) int {
+	if f.RGB565() {
+		return 2 * f.Width * f.Height
+	}
+	if f.Depth > 1 {
+		return grayRowBytes(f) * f.Height
+	}
+	return 2 * f.Height
+}
+
+// generateGrayC generuje tablicę const uint8_t z upakowanymi pikselami
+func generateGrayC(f *Font, opt exportOptions) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf(T("generatedAuto"), versionApp))
+	sb.WriteString(T("charSize"))
+	sb.WriteString(fmt.Sprintf("%dx%d, %d bpp\n\n", f.Width, f.Height, f.Depth))
+
+	sb.WriteString(fmt.Sprintf("#define %s_BPP %d\n\n", f.BaseName(), f.Depth))
+	sb.WriteString("const uint8_t " + f.BaseName() + "[] = {\n")
+	writeGrayRows(&sb, f, opt, "   ", "//")
+	sb.WriteString("};\n")
+	writeTablesC(&sb, f, opt)
+	if opt.Descriptor {
+		sb.WriteString(generateDescriptorC(f, opt))
+	}
+	return sb.String()
+}
+
+// generateGrayRust generuje statyczną tablicę [u8; N] z upakowanymi pikselami
+func generateGrayRust(f *Font, opt exportOptions) string {
+	var sb strings.Builder
+	name := strings.ToUpper(f.BaseName())
+
+	sb.WriteString(fmt.Sprintf(T("generatedAuto"), versionApp))
+	sb.WriteString(T("charSize"))
+	sb.WriteString(fmt.Sprintf("%dx%d, %d bpp\n\n", f.Width, f.Height, f.Depth))
+
+	writeConstsRust(&sb, f, opt, name)
+	sb.WriteString(fmt.Sprintf("pub const %s_BPP: usize = %d;\n\n", name, f.Depth))
+
+	sb.WriteString("#[rustfmt::skip]\n")
+	sb.WriteString(fmt.Sprintf("pub static %s: [u8; %d] = [\n", name, len(exportIndices(f, opt))*glyphBytes(f)))
+	writeGrayRows(&sb, f, opt, "    ", "//")
+	sb.WriteString("];\n")
+	writeTablesRust(&sb, f, opt, name)
+	if opt.Descriptor {
+		sb.WriteString(generateDescriptorRust(f, opt))
+	}
+	return sb.String()
+}
+
+// generateGrayGo generuje plik Go z wycinkiem []uint8 upakowanych pikseli
+func generateGrayGo(f *Font, opt exportOptions) string {
+	var sb strings.Builder
+	name := goFontName(f)
+
+	sb.WriteString(fmt.Sprintf(T("generatedAuto"), versionApp))
+	sb.WriteString(T("charSize"))
+	sb.WriteString(fmt.Sprintf("%dx%d, %d bpp\n\n", f.Width, f.Height, f.Depth))
+
+	sb.WriteString("package fonts\n\n")
+	writeConstsGo(&sb, f, opt, name)
+
+	sb.WriteString(fmt.Sprintf("var %s = []uint8{\n", name))
+	writeGrayRows(&sb, f, opt, "\t", "//")
+	sb.WriteString("}\n")
+	writeTablesGo(&sb, f, opt, name)
+	if opt.Descriptor {
+		sb.WriteString(generateDescriptorGo(f, opt))
+	}
+	return sb.String()
+}
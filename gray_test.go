@@ -0,0 +1,80 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// Piksele upakowane MSB first, wiersz dopełniony do pełnego bajtu
+func TestPackGray(t *testing.T) {
+	tests := []struct {
+		name  string
+		w     int
+		depth int
+		art   string
+		want  []byte
+	}{
+		{"2bpp 4px", 4, 2, "0123", []byte{0x1B}},
+		{"2bpp 5px", 5, 2, "32103", []byte{0xE4, 0xC0}},
+		{"4bpp 3px", 3, 4, "1?8", []byte{0x1F, 0x80}},
+	}
+	for _, tt := range tests {
+		f := NewGrayFont(tt.w, 1, tt.depth, levelsFrom(tt.art))
+		got := packGray(f.Glyph(0))
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: packed %02X, want %02X", tt.name, got, tt.want)
+			continue
+		}
+		values := make([]uint32, len(got))
+		for i, b := range got {
+			values[i] = uint32(b)
+		}
+		levels, err := unpackGray(values, tt.w, 1, tt.depth)
+		if err != nil || !slices.Equal(levels, levelsFrom(tt.art)) {
+			t.Errorf("%s: unpacked %v, %v", tt.name, levels, err)
+		}
+	}
+
+	if _, err := unpackGray([]uint32{1, 2, 3}, 5, 1, 2); err == nil {
+		t.Error("odd byte count accepted")
+	}
+}
+
+// Zmiana głębi przelicza piksele przez jasność; maska tuszu (Data) idzie za poziomami
+func TestSetDepth(t *testing.T) {
+	f := NewGrayFont(4, 1, 4, levelsFrom("07?8"))
+	f.SetDepth(2)
+	if got := f.Glyph(0).Levels(); !slices.Equal(got, levelsFrom("0132")) {
+		t.Errorf("4bpp→2bpp levels %v", got)
+	}
+	f.SetDepth(1)
+	if f.Depth != 1 || f.Pixels != nil || f.Data[0] != 0b0011 {
+		t.Errorf("2bpp→1bpp depth %d data %04b", f.Depth, f.Data[0])
+	}
+	f.SetDepth(4)
+	if got := f.Glyph(0).Levels(); !slices.Equal(got, levelsFrom("00??")) || f.Data[0] != 0b0011 {
+		t.Errorf("1bpp→4bpp levels %v data %04b", got, f.Data[0])
+	}
+}
+
+// Zmiana głębi jest jednym wpisem UNDO całego fontu
+func TestSetDepthUndo(t *testing.T) {
+	f := NewGrayFont(4, 1, 4, levelsFrom("07?8"))
+	h := &History{}
+	h.PushFont(f)
+	f.SetDepth(1)
+	if _, _, ok := h.Undo(f, 0, 0); !ok || f.Depth != 4 || !slices.Equal(f.Glyph(0).Levels(), levelsFrom("07?8")) {
+		t.Errorf("undo: depth %d levels %v", f.Depth, f.Glyph(0).Levels())
+	}
+}
+
+// Odcienie szarości wracają z pliku z tymi samymi poziomami (także z deskryptorem)
+func TestGrayRoundTrip(t *testing.T) {
+	for _, depth := range []int{2, 4} {
+		for _, opt := range []exportOptions{{}, {Art: true, Descriptor: true}} {
+			f := testFont(t, 6, 8, depth)
+			f.Glyph(f.Index('A')).SetLevel(0, 0, 1)
+			roundTrip(t, f, opt)
+		}
+	}
+}
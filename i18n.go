@@ -5,56 +5,406 @@ package main
 
 var Lang = map[string]map[string]string{
 	"PL": {
-		"chooseFile":      "  🗂️  Wybierz plik .h",
-		"noFile":          "Brak wczytanego pliku",
-		"loaded":          "Wczytano: ",
-		"glyph":           "Znak",
-		"editGlyph":       "✏️ Edytuj znak",
-		"scale":           "Skala",
-		"saveFont":        "💾 Zapisz cały font do .h",
-		"save":            "📤  Zamknij / Pokaż w formacie C",
-		"noData":          "Brak danych",
-		"loadFirst":       "Najpierw wczytaj plik .h",
-		"saved":           "Plik zapisany pomyślnie.",
-		"close":           "Zamknij",
-		"previewTitle":    "Znak %d w formacie C",
-		"editWindowTitle": "✏️  Edytuj znak %d",
+		"chooseFile":   "  🗂️  Wybierz plik .h",
+		"noFile":       "Brak wczytanego pliku",
+		"loaded":       "Wczytano: ",
+		"glyph":        "Znak",
+		"editGlyph":    "✏️ Edytuj znak",
+		"newTab":       "Nowy",
+		"proportional": "Font proporcjonalny",
+		"autoWidth":    "Auto z tuszu",
+		"glyphMetrics": "Szerokość: %d  Odstęp z lewej: %d",
+		// odcienie szarości
+		"depth":              "Głębia:",
+		"grayBadSize":        "Liczba bajtów (%d) nie jest wielokrotnością rozmiaru glifu (%d)",
+		"grayNoArray":        "Nie znaleziono tablicy z upakowanymi pikselami",
+		"binGrayUnsupported": "Eksport .bin obsługuje tylko fonty 1bpp - zapisz font w odcieniach szarości jako plik źródłowy",
+		"binIconUnsupported": "Eksport .bin obsługuje tylko fonty 1bpp - zapisz ikony RGB565 jako plik źródłowy (C, Rust, MicroPython lub Go)",
+		// ikony RGB565
+		"importIcons":  "  🎨  Importuj ikony RGB565",
+		"iconWidth":    "Szerokość ikony (1-16)",
+		"iconTooWide":  "Ikona ma %d px szerokości - obsługiwane są ikony do 16 px szerokości",
+		"iconHeight":   "Wysokość ikony",
+		"iconSwap":     "Zamiana bajtów (starszy pierwszy)",
+		"iconNext":     "Dalej",
+		"iconNoArray":  "Nie znaleziono tablicy z wartościami RGB565",
+		"iconBadSize":  "Liczba wartości (%d) nie jest wielokrotnością rozmiaru ikony (%d)",
+		"iconColor":    "🎨 Kolor",
+		"iconColorMsg": "Kolor rysowania",
+		// narzędzia rysowania
+		"tool_pen":      "✏️ Ołówek",
+		"tool_line":     "╱ Linia",
+		"tool_rect":     "▭ Prostokąt",
+		"tool_rectFill": "▬ Wypełniony",
+		"tool_ellipse":  "◯ Elipsa",
+		"tool_fill":     "🪣 Wypełnij",
+		"tool_select":   "⬚ Zaznacz",
+		// schowek
+		"copy":           "📋 Kopiuj",
+		"paste":          "📥 Wklej",
+		"glyphCopy":      "📋 Kopiuj glif",
+		"glyphPaste":     "📥 Wklej glif",
+		"clipboardEmpty": "Schowek jest pusty",
+		"copyArt":        "📋 Kopiuj jako ASCII",
+		"copyHex":        "📋 Kopiuj jako hex C",
+		"pasteText":      "📥 Wklej tekst",
+		"glyphTextCount": "Liczba wartości w schowku (%d) nie pasuje do glifu (oczekiwano %d)",
+		"glyphTextNone":  "W schowku nie ma rysunku ASCII ani linii hex glifu",
+		// przekształcenia
+		"transform":     "🔄 Przekształcenia",
+		"xfOperation":   "Przekształcenie",
+		"xfRange":       "Znaki (puste = bieżący glif)",
+		"xfApply":       "Zastosuj",
+		"xfNotSquare":   "Obrót o 90° wymaga kwadratowej komórki lub zaznaczenia",
+		"xf_mirror":     "⇆ Odbicie poziome",
+		"xf_flip":       "⇅ Odbicie pionowe",
+		"xf_rotateCW":   "↻ 90°",
+		"xf_rotateCCW":  "↺ 90°",
+		"xf_invert":     "◐ Negatyw",
+		"xf_shiftLeft":  "⇠ Przesuń",
+		"xf_shiftRight": "⇢ Przesuń",
+		"xf_shiftUp":    "⇡ Przesuń",
+		"xf_shiftDown":  "⇣ Przesuń",
+		// efekty fontu
+		"effects":        "✨ Efekty (nowy font)",
+		"fxEffect":       "Efekt",
+		"fxAmount":       "N",
+		"fxSample":       "Przykładowy napis",
+		"fxApply":        "Utwórz font",
+		"fxCell":         "Komórka: %dx%d",
+		"fxTooWide":      "Komórka po efekcie miałaby %d px szerokości (maksymalnie 16)",
+		"fx_bold":        "Pogrubienie",
+		"fx_italic":      "Pochylenie",
+		"fx_outline":     "Kontur",
+		"fx_shadow":      "Cień",
+		"fxHint_bold":    "Pogrubienie o N pikseli w prawo.",
+		"fxHint_italic":  "Wiersz przesunięty o piksel w prawo co N wierszy.",
+		"fxHint_outline": "Kontur 1 px wokół tuszu (N bez znaczenia).",
+		"fxHint_shadow":  "Cień przesunięty o N pikseli w prawo i w dół.",
+		// nowy font
+		"newFont":        "  🆕  Nowy font",
+		"newFontCreate":  "Utwórz",
+		"newFontBase":    "Font bazowy",
+		"newFontChars":   "Znaki",
+		"newFontRange":   "Zakres kodów",
+		"base_blank":     "Pusty",
+		"base_5x7":       "Wbudowany 5x7",
+		"base_8x8":       "Wbudowany 8x8 (IBM PC)",
+		"base_8x16":      "Wbudowany 8x16 (IBM VGA)",
+		"newFontLayout":  "Układ bitów",
+		"newFontSwapped": "zamienione bajty",
+		// strony kodowe
+		"codepage":             "Strona kodowa:",
+		"codepageNone":         "Unicode",
+		"codepageConvert":      "🔁 Konwertuj",
+		"codepageConvertApply": "Konwertuj",
+		"codepageTarget":       "Docelowa strona kodowa",
+		"codepageLost":         "%d glif(ów) z tuszem nie ma odpowiednika w docelowej stronie kodowej i został pominięty",
+		// lista glifów
+		"glyphInsert":     "➕ Wstaw",
+		"glyphDuplicate":  "⧉ Duplikuj",
+		"glyphDelete":     "🗑️ Usuń",
+		"glyphRenumber":   "Kolejne kody",
+		"glyphCode":       "Kod znaku:",
+		"glyphSetCode":    "Ustaw kod",
+		"glyphLastDelete": "Nie można usunąć ostatniego glifu fontu",
+		"codeUsed":        "Kod %d jest już użyty przez glif %d",
+		// rozmiar komórki
+		"resizeCell":      "↔️ Rozmiar komórki",
+		"resizeApply":     "Zmień",
+		"cellWidth":       "Szerokość (1-16)",
+		"cellHeight":      "Wysokość",
+		"anchor":          "Zaczepienie",
+		"anchor_topLeft":  "Lewy górny róg",
+		"anchor_center":   "Środek",
+		"anchor_baseline": "Linia bazowa",
+		"cellSizeBad":     "Niepoprawny rozmiar komórki (szerokość 1-16, wysokość 1-64)",
+		"resizeClipWarn":  "%d glif(ów) straci zapalone piksele poza nową komórką. Kontynuować?",
+		// metryki fontu
+		"metrics":            "📐 Metryki fontu",
+		"metricsAuto":        "Oszacuj z tuszu",
+		"metricsBad":         "Niepoprawna wartość metryki",
+		"metric_baseline":    "Linia bazowa (wiersz)",
+		"metric_ascent":      "Wydłużenie górne",
+		"metric_descent":     "Wydłużenie dolne",
+		"metric_xHeight":     "Wysokość x",
+		"metric_capHeight":   "Wysokość wersalików",
+		"metric_lineSpacing": "Odstęp między liniami",
+		"scale":              "Skala",
+		"saveFont":           "💾 Zapisz cały font do .h",
+		"save":               "📤  Zamknij / Pokaż w formacie C",
+		"noData":             "Brak danych",
+		"loadFirst":          "Najpierw wczytaj plik .h",
+		"saved":              "Plik zapisany pomyślnie.",
+		"close":              "Zamknij",
+		"previewTitle":       "Znak %d w formacie C",
+		"editWindowTitle":    "✏️  Edytuj znak %d",
 		// generowane wpisy
 		"editedCharAscii": "// Znak edytowany: ASCII ",
 		"generatedAuto":   "// Wygenerowano automatycznie — Font Preview v.%s\n",
 		"charSize":        "// Rozmiar znaków: ",
 		// błedy
-		"saveError": "Błąd zapisu",
+		"saveError":       "Błąd zapisu",
+		"sizeUnknown":     "Nie udało się ustalić rozmiaru znaków (np. nazwa tablicy FONT_8x16 lub stałe WIDTH / HEIGHT)",
+		"badBytesLiteral": "Niepoprawny literał bytes",
 		// nowe
 		"showGrid": "Pokaż siatkę",
 		"undo":     "⬅️  Cofnij",
 		"redo":     "➡️ Ponów",
+		// eksport
+		"exportFormat":   "Format",
+		"saveAction":     "Zapisz",
+		"cancel":         "Anuluj",
+		"asciiArt":       "Rysunek ASCII w komentarzu",
+		"descriptor":     "Struktura deskryptora",
+		"descriptorType": "Nazwa typu struktury",
+		// podzbiór znaków
+		"subset":           "Znaki",
+		"subset_all":       "Cały font",
+		"subset_range":     "Zakres",
+		"subset_set":       "Wybrane znaki",
+		"subset_text":      "Znaki użyte w tekście",
+		"subsetHint_all":   "",
+		"subsetHint_range": "np. 0-9, A-Z, 0x20-0x2F",
+		"subsetHint_set":   "np. 0123456789:.-",
+		"subsetHint_text":  "Wklej tekst, np. komunikaty z firmware",
+		"subsetBadRange":   "Niepoprawny zakres",
+		"subsetCodeMax":    "Kod znaku %s poza zakresem (największy 0x%X)",
+		"subsetEmpty":      "Brak znaków do zapisania",
+		"subsetReport":     "Zapisano %d z %d znaków, zaoszczędzono %d bajtów.",
+		"subsetMissing":    "Brak w foncie:",
+		"sourceSubset":     "🔎 Podzbiór ze źródeł",
+		"scanSource":       "Źródła",
+		"scanFolder":       "Folder…",
+		"scanFile":         "Plik…",
+		"scanAction":       "Skanuj",
+		"alwaysInclude":    "Zawsze dołącz",
+		"scanReport":       "Przeskanowano plików: %d, znalezionych znaków: %d.",
+		"scanContinue":     "Zapisać font z tymi znakami?",
+		"saveBinary":       "💾 Eksport .bin (flash) + nagłówek",
+		"binLayout":        "Układ",
+		"binAlign":         "Wyrównanie glifu",
+		"binOffset":        "Adres / offset",
+		"binCRC":           "CRC32",
+		// import binarny
+		"importBinary":  "  📥  Import .bin / Intel HEX",
+		"importAction":  "Importuj",
+		"binStride":     "Odstęp glifów (bajty)",
+		"glyphWidth":    "Szerokość",
+		"glyphHeight":   "Wysokość",
+		"glyphCount":    "Liczba znaków",
+		"binImportInfo": "Rozmiar: %d B, font: %d – %d",
+		"badHexRecord":  "Niepoprawny rekord Intel HEX",
+		// zapis z powrotem
+		"saveBack":         "💾 Zapisz z powrotem do oryginału",
+		"saveBackConfirm":  "Nadpisać wartości tablicy w pliku %s?",
+		"saveBackNoSource": "Font nie pochodzi z pliku źródłowego - użyj zapisu całego fontu.",
+		"saveBackNoArray":  "Nie znaleziono tablicy fontu w oryginalnym pliku",
+		"saveBackBytes":    "Tablice z literałami bytes nie są obsługiwane przy zapisie z powrotem",
+		"saveBackCount":    "Liczba wartości w pliku (%d) nie zgadza się z fontem (%d)",
+		"saveBackSize":     "Tablica w pliku opisuje komórkę %dx%d, a font ma %dx%d - użyj zapisu całego fontu",
+		"saveBackDepth":    "Tablica w pliku ma %d bpp, a font %d bpp - użyj zapisu całego fontu",
 	},
 	"EN": {
-		"chooseFile":      "  🗂️  Choose .h file",
-		"noFile":          "No file loaded",
-		"loaded":          "Loaded: ",
-		"glyph":           "Glyph",
-		"editGlyph":       "✏️ Edit glyph",
-		"scale":           "Scale",
-		"saveFont":        "💾 Save entire font to .h",
-		"save":            "📤  Close / Show in C format",
-		"noData":          "No data",
-		"loadFirst":       "Load .h file first",
-		"saved":           "File saved successfully.",
-		"close":           "Close",
-		"previewTitle":    "Glyph %d in C format",
-		"editWindowTitle": "✏️  Edit glyph %d",
+		"chooseFile":   "  🗂️  Choose .h file",
+		"noFile":       "No file loaded",
+		"loaded":       "Loaded: ",
+		"glyph":        "Glyph",
+		"editGlyph":    "✏️ Edit glyph",
+		"newTab":       "New",
+		"proportional": "Proportional font",
+		"autoWidth":    "Auto from ink",
+		"glyphMetrics": "Advance: %d  Left bearing: %d",
+		// grayscale
+		"depth":              "Depth:",
+		"grayBadSize":        "Byte count (%d) is not a multiple of the glyph size (%d)",
+		"grayNoArray":        "No packed pixel array found",
+		"binGrayUnsupported": "Binary export supports 1bpp fonts only - save grayscale fonts as source files",
+		"binIconUnsupported": "Binary export supports 1bpp fonts only - save RGB565 icons as source files (C, Rust, MicroPython or Go)",
+		// RGB565 icons
+		"importIcons":  "  🎨  Import RGB565 icons",
+		"iconWidth":    "Icon width (1-16)",
+		"iconTooWide":  "Icon is %d px wide - icons up to 16 px wide are supported",
+		"iconHeight":   "Icon height",
+		"iconSwap":     "Byte swap (high byte first)",
+		"iconNext":     "Next",
+		"iconNoArray":  "No RGB565 value array found",
+		"iconBadSize":  "Value count (%d) is not a multiple of the icon size (%d)",
+		"iconColor":    "🎨 Colour",
+		"iconColorMsg": "Drawing colour",
+		// drawing tools
+		"tool_pen":      "✏️ Pencil",
+		"tool_line":     "╱ Line",
+		"tool_rect":     "▭ Rectangle",
+		"tool_rectFill": "▬ Filled",
+		"tool_ellipse":  "◯ Ellipse",
+		"tool_fill":     "🪣 Fill",
+		"tool_select":   "⬚ Select",
+		// clipboard
+		"copy":           "📋 Copy",
+		"paste":          "📥 Paste",
+		"glyphCopy":      "📋 Copy glyph",
+		"glyphPaste":     "📥 Paste glyph",
+		"clipboardEmpty": "The clipboard is empty",
+		"copyArt":        "📋 Copy as ASCII",
+		"copyHex":        "📋 Copy as C hex",
+		"pasteText":      "📥 Paste text",
+		"glyphTextCount": "Clipboard value count (%d) does not match the glyph (expected %d)",
+		"glyphTextNone":  "The clipboard holds neither ASCII art nor a glyph hex line",
+		// transforms
+		"transform":     "🔄 Transforms",
+		"xfOperation":   "Transform",
+		"xfRange":       "Characters (empty = current glyph)",
+		"xfApply":       "Apply",
+		"xfNotSquare":   "90° rotation needs a square cell or selection",
+		"xf_mirror":     "⇆ Mirror",
+		"xf_flip":       "⇅ Flip",
+		"xf_rotateCW":   "↻ 90°",
+		"xf_rotateCCW":  "↺ 90°",
+		"xf_invert":     "◐ Invert",
+		"xf_shiftLeft":  "⇠ Shift",
+		"xf_shiftRight": "⇢ Shift",
+		"xf_shiftUp":    "⇡ Shift",
+		"xf_shiftDown":  "⇣ Shift",
+		// font effects
+		"effects":        "✨ Effects (new font)",
+		"fxEffect":       "Effect",
+		"fxAmount":       "N",
+		"fxSample":       "Sample text",
+		"fxApply":        "Create font",
+		"fxCell":         "Cell: %dx%d",
+		"fxTooWide":      "The cell would be %d px wide after the effect (16 max)",
+		"fx_bold":        "Bold",
+		"fx_italic":      "Italic",
+		"fx_outline":     "Outline",
+		"fx_shadow":      "Shadow",
+		"fxHint_bold":    "Embolden by N pixels to the right.",
+		"fxHint_italic":  "Each row shifted right by one pixel every N rows.",
+		"fxHint_outline": "1 px outline around the ink (N is ignored).",
+		"fxHint_shadow":  "Shadow offset by N pixels right and down.",
+		// new font
+		"newFont":        "  🆕  New font",
+		"newFontCreate":  "Create",
+		"newFontBase":    "Base font",
+		"newFontChars":   "Characters",
+		"newFontRange":   "Code range",
+		"base_blank":     "Blank",
+		"base_5x7":       "Built-in 5x7",
+		"base_8x8":       "Built-in 8x8 (IBM PC)",
+		"base_8x16":      "Built-in 8x16 (IBM VGA)",
+		"newFontLayout":  "Bit layout",
+		"newFontSwapped": "swapped bytes",
+		// codepages
+		"codepage":             "Codepage:",
+		"codepageNone":         "Unicode",
+		"codepageConvert":      "🔁 Convert",
+		"codepageConvertApply": "Convert",
+		"codepageTarget":       "Target codepage",
+		"codepageLost":         "%d inked glyph(s) have no counterpart in the target codepage and were dropped",
+		// glyph list
+		"glyphInsert":     "➕ Insert",
+		"glyphDuplicate":  "⧉ Duplicate",
+		"glyphDelete":     "🗑️ Delete",
+		"glyphRenumber":   "Sequential codes",
+		"glyphCode":       "Character code:",
+		"glyphSetCode":    "Set code",
+		"glyphLastDelete": "Cannot delete the last glyph of the font",
+		"codeUsed":        "Code %d is already used by glyph %d",
+		// cell size
+		"resizeCell":      "↔️ Cell size",
+		"resizeApply":     "Resize",
+		"cellWidth":       "Width (1-16)",
+		"cellHeight":      "Height",
+		"anchor":          "Anchor",
+		"anchor_topLeft":  "Top-left",
+		"anchor_center":   "Centre",
+		"anchor_baseline": "Baseline",
+		"cellSizeBad":     "Invalid cell size (width 1-16, height 1-64)",
+		"resizeClipWarn":  "%d glyph(s) will lose lit pixels outside the new cell. Continue?",
+		// font metrics
+		"metrics":            "📐 Font metrics",
+		"metricsAuto":        "Estimate from ink",
+		"metricsBad":         "Invalid metric value",
+		"metric_baseline":    "Baseline (row)",
+		"metric_ascent":      "Ascent",
+		"metric_descent":     "Descent",
+		"metric_xHeight":     "x-height",
+		"metric_capHeight":   "Cap height",
+		"metric_lineSpacing": "Line spacing",
+		"scale":              "Scale",
+		"saveFont":           "💾 Save entire font to .h",
+		"save":               "📤  Close / Show in C format",
+		"noData":             "No data",
+		"loadFirst":          "Load .h file first",
+		"saved":              "File saved successfully.",
+		"close":              "Close",
+		"previewTitle":       "Glyph %d in C format",
+		"editWindowTitle":    "✏️  Edit glyph %d",
 		// generated text
 		"editedCharAscii": "// Edited character: ASCII ",
 		"generatedAuto":   "// Automatically generated — Font Preview v.%s\n",
 		"charSize":        "// Character size: ",
 		// errors
-		"saveError": "Save error",
+		"saveError":       "Save error",
+		"sizeUnknown":     "Could not determine glyph size (e.g. array name FONT_8x16 or WIDTH / HEIGHT constants)",
+		"badBytesLiteral": "Invalid bytes literal",
 		// new
 		"showGrid": "Show grid",
 		"undo":     "⬅️ Undo",
 		"redo":     "⬅️ Redo",
+		// export
+		"exportFormat":   "Format",
+		"saveAction":     "Save",
+		"cancel":         "Cancel",
+		"asciiArt":       "ASCII-art comment",
+		"descriptor":     "Descriptor struct",
+		"descriptorType": "Struct type name",
+		// character subset
+		"subset":           "Characters",
+		"subset_all":       "Entire font",
+		"subset_range":     "Range",
+		"subset_set":       "Selected characters",
+		"subset_text":      "Characters used in text",
+		"subsetHint_all":   "",
+		"subsetHint_range": "e.g. 0-9, A-Z, 0x20-0x2F",
+		"subsetHint_set":   "e.g. 0123456789:.-",
+		"subsetHint_text":  "Paste text, e.g. firmware messages",
+		"subsetBadRange":   "Invalid range",
+		"subsetCodeMax":    "Character code %s out of range (largest 0x%X)",
+		"subsetEmpty":      "No characters to save",
+		"subsetReport":     "Saved %d of %d glyphs, %d bytes saved.",
+		"subsetMissing":    "Missing in font:",
+		"sourceSubset":     "🔎 Subset from sources",
+		"scanSource":       "Sources",
+		"scanFolder":       "Folder…",
+		"scanFile":         "File…",
+		"scanAction":       "Scan",
+		"alwaysInclude":    "Always include",
+		"scanReport":       "Scanned %d files, found %d characters.",
+		"scanContinue":     "Save the font with these characters?",
+		"saveBinary":       "💾 Export .bin (flash) + header",
+		"binLayout":        "Layout",
+		"binAlign":         "Glyph alignment",
+		"binOffset":        "Address / offset",
+		"binCRC":           "CRC32",
+		// binary import
+		"importBinary":  "  📥  Import .bin / Intel HEX",
+		"importAction":  "Import",
+		"binStride":     "Glyph stride (bytes)",
+		"glyphWidth":    "Width",
+		"glyphHeight":   "Height",
+		"glyphCount":    "Glyph count",
+		"binImportInfo": "Size: %d B, font: %d – %d",
+		"badHexRecord":  "Invalid Intel HEX record",
+		// save back
+		"saveBack":         "💾 Save back to original",
+		"saveBackConfirm":  "Overwrite array values in %s?",
+		"saveBackNoSource": "Font was not loaded from a source file - use save entire font.",
+		"saveBackNoArray":  "Font array not found in the original file",
+		"saveBackBytes":    "Arrays with bytes literals are not supported by save back",
+		"saveBackCount":    "Number of values in file (%d) does not match the font (%d)",
+		"saveBackSize":     "The array in the file describes a %dx%d cell, but the font is %dx%d - use save entire font",
+		"saveBackDepth":    "The array in the file is %d bpp, but the font is %d bpp - use save entire font",
 	},
 }
 
@@ -0,0 +1,311 @@
+/* ============================================================================
+
+    Ikony RGB565
+    Kolorowe ikony dla wyświetlaczy TFT (ST7735, ILI9341...) – każdy piksel
+    to 16-bitowa wartość koloru RRRRRGGGGGGBBBBB
+    – NewIconFont, rgb565Color / colorToRGB565, parseIconSource,
+      generatory C / Rust / MicroPython / Go, iconImportDialog
+
+    Ikony używają tego samego modelu co odcienie szarości: Depth = 16,
+    Font.Pixels trzyma wartości RGB565, a Font.Data maskę pikseli różnych
+    od zera (0x0000 = czarne tło). Font.Swap zamienia kolejność bajtów
+    każdej wartości przy odczycie i zapisie – większość sterowników SPI
+    oczekuje najpierw starszego bajtu. Maska wiersza to uint16, więc
+    ikona ma najwyżej 16 px szerokości (jak glif fontu).
+
+=========================================================================== */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Głębia oznaczająca tryb kolorowych ikon RGB565
+const depthRGB565 = 16
+
+// Znacznik trybu RGB565 w eksporcie: "#define ICONS_16x16_RGB565_SWAP 1",
+// "RGB565_SWAP = 0", "Icons16x16RGB565Swap = true"
+var iconSwapRE = regexp.MustCompile(`(?i)rgb565_?swap\w*\s*(?::\s*\w+\s*)?=?\s*(\w+)`)
+
+// Tablica C 16-bitowa: "const uint16_t ICONS_16x16[] = {"
+var cWordDeclRE = regexp.MustCompile(`(?:uint16_t|unsigned\s+short)\s+(\w+)\s*\[[^\]]*\]\s*=\s*\{`)
+
+// RGB565 zwraca true dla fontu w trybie kolorowych ikon
+func (f *Font) RGB565() bool {
+	return f.Depth == depthRGB565
+}
+
+// NewIconFont tworzy font ikon z wartości RGB565 zapisanych w pliku
+// (Width*Height na ikonę); swap = wartości z zamienionymi bajtami
+func NewIconFont(w, h int, values []uint16, swap bool) *Font {
+	if swap {
+		for i, v := range values {
+			values[i] = swap16(v)
+		}
+	}
+	f := NewGrayFont(w, h, depthRGB565, values)
+	f.Swap = swap
+	return f
+}
+
+// swap16 zamienia kolejność bajtów wartości 16-bitowej
+func swap16(v uint16) uint16 {
+	return v<<8 | v>>8
+}
+
+// rgb565Color zamienia wartość RGB565 na kolor (składowe rozciągnięte do 8 bitów)
+func rgb565Color(v uint16) color.NRGBA {
+	r, g, b := uint8(v>>11&0x1F), uint8(v>>5&0x3F), uint8(v&0x1F)
+	return color.NRGBA{R: r<<3 | r>>2, G: g<<2 | g>>4, B: b<<3 | b>>2, A: 0xFF}
+}
+
+// colorToRGB565 zamienia dowolny kolor na wartość RGB565
+func colorToRGB565(c color.Color) uint16 {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return uint16(n.R>>3)<<11 | uint16(n.G>>2)<<5 | uint16(n.B>>3)
+}
+
+// grayRGB565 zwraca szary kolor RGB565 o jasności 0..255
+func grayRGB565(v int) uint16 {
+	y := uint8(max(0, min(v, 255)))
+	return colorToRGB565(color.Gray{Y: y})
+}
+
+// luminance565 zwraca jasność koloru RGB565 w skali 0..255
+func luminance565(v uint16) int {
+	c := rgb565Color(v)
+	return (299*int(c.R) + 587*int(c.G) + 114*int(c.B)) / 1000
+}
+
+// exportPixels zwraca wartości pikseli glifu w kolejności bajtów pliku (Font.Swap)
+func exportPixels(g Glyph) []uint16 {
+	values := g.Levels()
+	if g.Font.Swap {
+		for i, v := range values {
+			values[i] = swap16(v)
+		}
+	}
+	return values
+}
+
+// iconSwapFromSource sprawdza znacznik RGB565 w źródle i zwraca zapisaną zamianę bajtów
+func iconSwapFromSource(src string) (swap, ok bool) {
+	m := iconSwapRE.FindStringSubmatch(src)
+	if m == nil {
+		return false, false
+	}
+	v := strings.ToLower(m[1])
+	return v == "1" || v == "true", true
+}
+
+// parseIconSource odczytuje tablicę wartości RGB565 (C, Rust, Python lub Go).
+// Wymiary z nazwy tablicy lub stałych; w == 0 / h == 0 wymusza podanie ich w źródle.
+// Literały bytes Pythona składane są z par bajtów (młodszy pierwszy).
+func parseIconSource(src string, w, h int, swap bool) (*Font, error) {
+	var name, body string
+	if m := cWordDeclRE.FindStringSubmatchIndex(src); m != nil {
+		name = src[m[2]:m[3]]
+		body = initializerBody(src, m[1]-1, false)
+	} else if n, _, b, ok := findArray(src); ok {
+		name, body = n, b
+	} else {
+		return nil, errors.New(T("iconNoArray"))
+	}
+
+	if w == 0 || h == 0 {
+		w, h = sizeFromName(name)
+		if w == 0 || h == 0 {
+			w, h = sizeFromMeta(src)
+		}
+	}
+	if w <= 0 || h <= 0 {
+		return nil, errors.New(T("sizeUnknown"))
+	}
+	if w > 16 {
+		return nil, fmt.Errorf(T("iconTooWide"), w)
+	}
+
+	raw, bytesLit, err := parseInitializer(body)
+	if err != nil {
+		return nil, err
+	}
+	var values []uint16
+	if bytesLit {
+		for i := 0; i+1 < len(raw); i += 2 {
+			values = append(values, uint16(raw[i])|uint16(raw[i+1])<<8)
+		}
+	} else {
+		for _, v := range raw {
+			values = append(values, uint16(v))
+		}
+	}
+	if len(values) == 0 || len(values)%(w*h) != 0 {
+		return nil, fmt.Errorf(T("iconBadSize"), len(values), w*h)
+	}
+	return NewIconFont(w, h, values, swap), nil
+}
+
+// writeIconRows zapisuje wartości RGB565 ikon, jeden wiersz pikseli w linii
+func writeIconRows(sb *strings.Builder, f *Font, opt exportOptions, indent, comment string) {
+	for _, i := range exportIndices(f, opt) {
+		g := f.Glyph(i)
+		if opt.Art {
+			writeGlyphArt(sb, g, indent, "/*")
+		}
+		if lbl := g.Label(); lbl != "" {
+			sb.WriteString(indent + comment + " " + lbl + "\n")
+		}
+		values := exportPixels(g)
+		for y := 0; y < f.Height; y++ {
+			sb.WriteString(indent)
+			for _, v := range values[y*f.Width : (y+1)*f.Width] {
+				sb.WriteString(fmt.Sprintf("0x%04X,", v))
+			}
+			sb.WriteString("\n")
+		}
+	}
+}
+
+// swapFlag zwraca zapis znacznika zamiany bajtów (0 / 1)
+func swapFlag(f *Font) int {
+	if f.Swap {
+		return 1
+	}
+	return 0
+}
+
+// generateIconC generuje tablicę const uint16_t z wartościami RGB565
+func generateIconC(f *Font, opt exportOptions) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf(T("generatedAuto"), versionApp))
+	sb.WriteString(T("charSize"))
+	sb.WriteString(fmt.Sprintf("%dx%d, RGB565\n\n", f.Width, f.Height))
+
+	sb.WriteString(fmt.Sprintf("#define %s_RGB565_SWAP %d\n\n", f.BaseName(), swapFlag(f)))
+	sb.WriteString("const uint16_t " + f.BaseName() + "[] = {\n")
+	writeIconRows(&sb, f, opt, "   ", "//")
+	sb.WriteString("};\n")
+	writeTablesC(&sb, f, opt)
+	if opt.Descriptor {
+		sb.WriteString(generateDescriptorC(f, opt))
+	}
+	return sb.String()
+}
+
+// generateIconRust generuje statyczną tablicę [u16; N] z wartościami RGB565
+func generateIconRust(f *Font, opt exportOptions) string {
+	var sb strings.Builder
+	name := strings.ToUpper(f.BaseName())
+
+	sb.WriteString(fmt.Sprintf(T("generatedAuto"), versionApp))
+	sb.WriteString(T("charSize"))
+	sb.WriteString(fmt.Sprintf("%dx%d, RGB565\n\n", f.Width, f.Height))
+
+	writeConstsRust(&sb, f, opt, name)
+	sb.WriteString(fmt.Sprintf("pub const %s_RGB565_SWAP: bool = %t;\n\n", name, f.Swap))
+
+	sb.WriteString("#[rustfmt::skip]\n")
+	sb.WriteString(fmt.Sprintf("pub static %s: [u16; %d] = [\n", name, len(exportIndices(f, opt))*f.Width*f.Height))
+	writeIconRows(&sb, f, opt, "    ", "//")
+	sb.WriteString("];\n")
+	writeTablesRust(&sb, f, opt, name)
+	if opt.Descriptor {
+		sb.WriteString(generateDescriptorRust(f, opt))
+	}
+	return sb.String()
+}
+
+// generateIconGo generuje plik Go z wycinkiem []uint16 wartości RGB565
+func generateIconGo(f *Font, opt exportOptions) string {
+	var sb strings.Builder
+	name := goFontName(f)
+
+	sb.WriteString(fmt.Sprintf(T("generatedAuto"), versionApp))
+	sb.WriteString(T("charSize"))
+	sb.WriteString(fmt.Sprintf("%dx%d, RGB565\n\n", f.Width, f.Height))
+
+	sb.WriteString("package fonts\n\n")
+	writeConstsGo(&sb, f, opt, name)
+
+	sb.WriteString(fmt.Sprintf("var %s = []uint16{\n", name))
+	writeIconRows(&sb, f, opt, "\t", "//")
+	sb.WriteString("}\n")
+	writeTablesGo(&sb, f, opt, name)
+	if opt.Descriptor {
+		sb.WriteString(generateDescriptorGo(f, opt))
+	}
+	return sb.String()
+}
+
+// iconBytes zwraca bajty ikony dla MicroPython (framebuf.RGB565, młodszy bajt pierwszy)
+func iconBytes(g Glyph) []byte {
+	var out []byte
+	for _, v := range exportPixels(g) {
+		out = append(out, byte(v), byte(v>>8))
+	}
+	return out
+}
+
+// Wywoływane przy kliknięciu "Importuj ikony RGB565": wymiary ikony,
+// zamiana bajtów i plik źródłowy z tablicą wartości 16-bitowych
+func iconImportDialog(w fyne.Window, onLoaded func(f *Font, name string)) {
+	widthEntry := widget.NewEntry()
+	widthEntry.SetText("16")
+	heightEntry := widget.NewEntry()
+	heightEntry.SetText("16")
+	swapCheck := widget.NewCheck("", nil)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(T("iconWidth"), widthEntry),
+		widget.NewFormItem(T("iconHeight"), heightEntry),
+		widget.NewFormItem(T("iconSwap"), swapCheck),
+	}
+	dialog.ShowForm(T("importIcons"), T("iconNext"), T("cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		iw, errW := strconv.Atoi(strings.TrimSpace(widthEntry.Text))
+		ih, errH := strconv.Atoi(strings.TrimSpace(heightEntry.Text))
+		if errW != nil || errH != nil || iw <= 0 || ih <= 0 {
+			dialog.ShowError(errors.New(T("sizeUnknown")), w)
+			return
+		}
+		if iw > 16 {
+			dialog.ShowError(fmt.Errorf(T("iconTooWide"), iw), w)
+			return
+		}
+
+		dialog.ShowFileOpen(func(rc fyne.URIReadCloser, _ error) {
+			if rc == nil {
+				return
+			}
+			defer func() { _ = rc.Close() }()
+
+			src, err := io.ReadAll(rc)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			f, err := parseIconSource(string(src), iw, ih, swapCheck.Checked)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			f.SourceText = string(src)
+			f.SourceURI = rc.URI()
+			onLoaded(f, rc.URI().Name())
+		}, w)
+	}, w)
+}
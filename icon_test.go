@@ -0,0 +1,89 @@
+package main
+
+import (
+	"image/color"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRGB565Colors(t *testing.T) {
+	tests := []struct {
+		v    uint16
+		c    color.NRGBA
+		luma int
+	}{
+		{0x0000, color.NRGBA{0, 0, 0, 0xFF}, 0},
+		{0xFFFF, color.NRGBA{0xFF, 0xFF, 0xFF, 0xFF}, 255},
+		{0xF800, color.NRGBA{0xFF, 0, 0, 0xFF}, 76},
+		{0x07E0, color.NRGBA{0, 0xFF, 0, 0xFF}, 149},
+		{0x001F, color.NRGBA{0, 0, 0xFF, 0xFF}, 29},
+	}
+	for _, tt := range tests {
+		if c := rgb565Color(tt.v); c != tt.c || colorToRGB565(c) != tt.v || luminance565(tt.v) != tt.luma {
+			t.Errorf("%04X: colour %v luminance %d", tt.v, c, luminance565(tt.v))
+		}
+	}
+	if swap16(0x12AB) != 0xAB12 {
+		t.Error("swap16")
+	}
+	if g := grayRGB565(128); luminance565(g) < 120 || luminance565(g) > 136 {
+		t.Errorf("grayRGB565(128) = %04X", g)
+	}
+}
+
+// Zamiana bajtów zmienia tylko zapis: kolory w foncie te same, w pliku bajty zamienione
+func TestIconSwap(t *testing.T) {
+	f := NewIconFont(2, 1, []uint16{0x00F8, 0x1F00}, true)
+	if !slices.Equal(f.Pixels, []uint16{0xF800, 0x001F}) {
+		t.Fatalf("pixels %04X", f.Pixels)
+	}
+	if got := exportPixels(f.Glyph(0)); !slices.Equal(got, []uint16{0x00F8, 0x1F00}) {
+		t.Errorf("exported %04X", got)
+	}
+
+	h := &History{}
+	h.PushFont(f)
+	f.Swap = false
+	if _, _, ok := h.Undo(f, 0, 0); !ok || !f.Swap {
+		t.Error("swap toggle not undone")
+	}
+}
+
+func TestParseIconSourceErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"too wide", "const uint16_t ICONS_20x2[] = {0, 0};"},
+		{"no size", "const uint16_t ICONS[] = {0, 0};"},
+		{"partial icon", "const uint16_t ICONS_2x2[] = {0, 0, 0};"},
+		{"no array", "int x = 0;"},
+	}
+	for _, tt := range tests {
+		if _, err := parseIconSource(tt.src, 0, 0, false); err == nil {
+			t.Errorf("%s: accepted", tt.name)
+		}
+	}
+}
+
+// Ikony RGB565 wracają z tymi samymi wartościami pikseli i znacznikiem zamiany bajtów
+func TestExportImportIcons(t *testing.T) {
+	values := make([]uint16, 2*4*3)
+	for i := range values {
+		values[i] = uint16(i * 0x0841)
+	}
+	for _, swap := range []bool{false, true} {
+		for _, format := range exportFormats {
+			want := NewIconFont(4, 3, values, swap)
+			src := generateFontSource(want, format, exportOptions{})
+			got, err := parseHeaderWithSize(strings.NewReader(src))
+			if err != nil {
+				t.Fatalf("%s swap=%t: %v\n%s", format, swap, err, src)
+			}
+			if got.Swap != swap || !slices.Equal(got.Pixels, want.Pixels) {
+				t.Errorf("%s swap=%t: got %04X (swap %t), want %04X", format, swap, got.Pixels, got.Swap, want.Pixels)
+			}
+		}
+	}
+}
@@ -0,0 +1,352 @@
+/* ============================================================================
+
+    Import fontu
+    Odczyt tablic fontów zapisanych w różnych językach
+    – C (uint16_t), Rust ([u8; N] / [u16; N]), Python (listy, bytes), Go ([]uint16 / []byte)
+
+    Tablice 16-bitowe: jeden element = jeden wiersz glifu (jak w C).
+    Tablice 8-bitowe: wiersz zajmuje (szerokość+7)/8 bajtów, MSB first,
+    wyrównany do lewej (układ framebuf.MONO_HLSB, zgodny z eksportem).
+
+=========================================================================== */
+
+package main
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Deklaracje tablic w obsługiwanych językach
+var (
+	cDeclRE    = regexp.MustCompile(`(?i)uint16_t\s+(\w+)`)
+	rustDeclRE = regexp.MustCompile(`(?:static|const)\s+(?:mut\s+)?(\w+)\s*:\s*&?(?:'static\s+)?\[\s*(u8|u16)\s*(?:;[^\]]*)?\]\s*=\s*(?:&\s*)?\[`)
+	goDeclRE   = regexp.MustCompile(`(\w+)\s*(?:\[[^\]]*\]\w+\s*)?:?=\s*\[[^\]]*\](uint16|uint8|byte)\s*\{`)
+	pyDeclRE   = regexp.MustCompile(`(?m)^[ \t]*(\w+)[ \t]*=[ \t]*(?:(?:bytes|bytearray)\s*\(\s*)?(?:\[|\(|[bB]['"])`)
+)
+
+// Wymiary znaków: w nazwie tablicy ("ALGER_16x16", "Font8x16") lub w stałych ("WIDTH = 8")
+var (
+	sizeNameRE   = regexp.MustCompile(`(\d+)[xX](\d+)$`)
+	widthMetaRE  = regexp.MustCompile(`(?i)\b\w*width\w*\s*(?::\s*\w+\s*)?=\s*(\d+)`)
+	heightMetaRE = regexp.MustCompile(`(?i)\b\w*height\w*\s*(?::\s*\w+\s*)?=\s*(\d+)`)
+	numberRE     = regexp.MustCompile(`0[xX][0-9A-Fa-f_]+|0[bB][01_]+|[0-9][0-9_]*`)
+	hexRE        = regexp.MustCompile(`0x[0-9A-Fa-f]+`)
+)
+
+// parseFontSource rozpoznaje język źródła i odczytuje tablicę fontu
+func parseFontSource(src string) ([]uint16, int, int, error) {
+	name, bits, body, ok := findArray(src)
+	if !ok {
+		// Brak deklaracji Rust / Go / Python - klasyczny plik C
+		return parseCHex(src)
+	}
+
+	gw, gh := sizeFromName(name)
+	if gw == 0 || gh == 0 {
+		gw, gh = sizeFromMeta(src)
+	}
+
+	values, bytesLit, err := parseInitializer(body)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if bits == 0 {
+		// Python: literał bytes lub wartości mieszczące się w bajcie
+		bits = 8
+		if !bytesLit {
+			for _, v := range values {
+				if v > 0xFF {
+					bits = 16
+					break
+				}
+			}
+		}
+	}
+
+	if bits == 16 {
+		nums := make([]uint16, len(values))
+		for i, v := range values {
+			nums[i] = uint16(v)
+		}
+		return nums, gw, gh, nil
+	}
+	if gw == 0 {
+		return nil, 0, 0, errors.New(T("sizeUnknown"))
+	}
+	nums, err := packBytes(values, gw)
+	return nums, gw, gh, err
+}
+
+// parseCHex odczytuje plik C: nazwa uint16_t NAZWA_WxH i liczby z inicjalizatora
+// tablicy (te same, które podmienia zapis z powrotem); plik bez deklaracji
+// tablicy - wszystkie liczby hex w pliku
+func parseCHex(src string) ([]uint16, int, int, error) {
+	var gw, gh int
+	if match := cDeclRE.FindStringSubmatch(src); len(match) > 1 {
+		gw, gh = sizeFromName(match[1])
+	}
+
+	var body string
+	if open, ok := cArrayOpen(src); ok {
+		body = initializerBody(src, open, false)
+	} else {
+		body = strings.Join(hexRE.FindAllString(src, -1), ",")
+	}
+	values, _, err := parseInitializer(body)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	nums := make([]uint16, len(values))
+	for i, v := range values {
+		if v > 0xFFFF {
+			return nil, 0, 0, strconv.ErrRange
+		}
+		nums[i] = uint16(v)
+	}
+	return nums, gw, gh, nil
+}
+
+// cArrayOpen zwraca pozycję nawiasu otwierającego inicjalizator tablicy uint16_t
+func cArrayOpen(src string) (int, bool) {
+	m := cDeclRE.FindStringIndex(src)
+	if m == nil {
+		return 0, false
+	}
+	brace := strings.IndexByte(src[m[1]:], '{')
+	if brace < 0 {
+		return 0, false
+	}
+	return m[1] + brace, true
+}
+
+// findArray szuka deklaracji tablicy Rust, Go lub Python i zwraca jej nazwę,
+// szerokość elementu (0 = nieznana) oraz zawartość inicjalizatora bez komentarzy
+func findArray(src string) (string, int, string, bool) {
+	if m := rustDeclRE.FindStringSubmatchIndex(src); m != nil {
+		bits := 16
+		if src[m[4]:m[5]] == "u8" {
+			bits = 8
+		}
+		body := initializerBody(src, m[1]-1, false)
+		return src[m[2]:m[3]], bits, body, true
+	}
+	if m := goDeclRE.FindStringSubmatchIndex(src); m != nil {
+		bits := 16
+		if src[m[4]:m[5]] != "uint16" {
+			bits = 8
+		}
+		body := initializerBody(src, m[1]-1, false)
+		return src[m[2]:m[3]], bits, body, true
+	}
+	if cDeclRE.MatchString(src) {
+		return "", 0, "", false
+	}
+	if m := pyDeclRE.FindStringSubmatchIndex(src); m != nil {
+		open := m[1] - 1
+		if src[open] == '\'' || src[open] == '"' {
+			open-- // literał b'...' bez nawiasu - cofamy na prefiks
+		}
+		body := initializerBody(src, open, true)
+		return src[m[2]:m[3]], 0, body, true
+	}
+	return "", 0, "", false
+}
+
+// initializerBody zwraca tekst od nawiasu otwierającego na pozycji open do
+// pasującego nawiasu zamykającego. Komentarze są usuwane, literały napisów zachowane.
+func initializerBody(src string, open int, python bool) string {
+	var sb strings.Builder
+	depth := 0
+	for i := open; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '\'' || c == '"':
+			// literał napisu - kopiujemy do zamykającego cudzysłowu
+			j := i + 1
+			for j < len(src) && src[j] != c {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) {
+				j = len(src) - 1
+			}
+			sb.WriteString(src[i : j+1])
+			i = j
+			if depth == 0 {
+				return sb.String()
+			}
+		case python && c == '#', !python && c == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			sb.WriteByte('\n')
+		case !python && c == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return sb.String()
+			}
+			i += end + 3
+			sb.WriteByte(' ')
+		case c == '(' || c == '[' || c == '{':
+			depth++
+			sb.WriteByte(c)
+		case c == ')' || c == ']' || c == '}':
+			depth--
+			sb.WriteByte(c)
+			if depth <= 0 {
+				return sb.String()
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// parseInitializer odczytuje liczby i literały bytes z zawartości inicjalizatora
+func parseInitializer(body string) ([]uint32, bool, error) {
+	var values []uint32
+	bytesLit := false
+
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case (c == 'b' || c == 'B') && i+1 < len(body) && (body[i+1] == '\'' || body[i+1] == '"'):
+			// literał Pythona b'\x00\x18...'
+			j := i + 2
+			for j < len(body) && body[j] != body[i+1] {
+				if body[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j > len(body) {
+				j = len(body)
+			}
+			bs, err := unescapeBytes(body[i+2 : j])
+			if err != nil {
+				return nil, false, err
+			}
+			for _, b := range bs {
+				values = append(values, uint32(b))
+			}
+			bytesLit = true
+			i = j
+		case c >= '0' && c <= '9':
+			m := numberRE.FindString(body[i:])
+			v, err := strconv.ParseUint(strings.ReplaceAll(m, "_", ""), 0, 32)
+			if err != nil {
+				return nil, false, err
+			}
+			values = append(values, uint32(v))
+			// pomijamy sufiks typu (np. 0x18u8)
+			i += len(m)
+			for i < len(body) && isIdentByte(body[i]) {
+				i++
+			}
+			i--
+		case isIdentByte(c):
+			// identyfikatory (bytes, join, ...) nie są danymi
+			for i < len(body) && isIdentByte(body[i]) {
+				i++
+			}
+			i--
+		}
+	}
+	return values, bytesLit, nil
+}
+
+// unescapeBytes dekoduje zawartość literału bytes Pythona
+func unescapeBytes(s string) ([]byte, error) {
+	var out []byte
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			out = append(out, s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'x':
+			if i+3 > len(s) {
+				return nil, errors.New(T("badBytesLiteral"))
+			}
+			v, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+			if err != nil {
+				return nil, errors.New(T("badBytesLiteral"))
+			}
+			out = append(out, byte(v))
+			i += 2
+		case 'n':
+			out = append(out, '\n')
+		case 'r':
+			out = append(out, '\r')
+		case 't':
+			out = append(out, '\t')
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			j := i
+			for j < len(s) && j < i+3 && s[j] >= '0' && s[j] <= '7' {
+				j++
+			}
+			v, _ := strconv.ParseUint(s[i:j], 8, 8)
+			out = append(out, byte(v))
+			i = j - 1
+		default:
+			out = append(out, s[i])
+		}
+	}
+	return out, nil
+}
+
+// packBytes składa bajty w wiersze uint16 (MSB first, wiersz wyrównany do lewej)
+func packBytes(values []uint32, gw int) ([]uint16, error) {
+	bytesPerRow := (gw + 7) / 8
+	if bytesPerRow > 2 {
+		return nil, errors.New(T("sizeUnknown"))
+	}
+	pad := bytesPerRow*8 - gw
+
+	nums := make([]uint16, 0, len(values)/bytesPerRow)
+	for i := 0; i+bytesPerRow <= len(values); i += bytesPerRow {
+		var row uint32
+		for b := 0; b < bytesPerRow; b++ {
+			row = row<<8 | values[i+b]&0xFF
+		}
+		nums = append(nums, uint16(row>>pad))
+	}
+	return nums, nil
+}
+
+// sizeFromName odczytuje wymiary z końcówki nazwy tablicy, np. "ALGER_16x16"
+func sizeFromName(name string) (int, int) {
+	m := sizeNameRE.FindStringSubmatch(name)
+	if m == nil {
+		return 0, 0
+	}
+	w, err1 := strconv.Atoi(m[1])
+	h, err2 := strconv.Atoi(m[2])
+	if err1 != nil || err2 != nil {
+		return 0, 0
+	}
+	return w, h
+}
+
+// sizeFromMeta odczytuje wymiary ze stałych WIDTH / HEIGHT w pliku
+func sizeFromMeta(src string) (int, int) {
+	var w, h int
+	if m := widthMetaRE.FindStringSubmatch(src); m != nil {
+		w, _ = strconv.Atoi(m[1])
+	}
+	if m := heightMetaRE.FindStringSubmatch(src); m != nil {
+		h, _ = strconv.Atoi(m[1])
+	}
+	return w, h
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// sameFont porównuje piksele, kody i szerokości dwóch fontów oraz metryki,
+// jeśli zostały zapisane (deskryptor lub stałe MicroPython)
+func sameFont(t *testing.T, got, want *Font, metrics bool) {
+	t.Helper()
+	if got.Width != want.Width || got.Height != want.Height || got.Count() != want.Count() {
+		t.Fatalf("got %dx%d × %d, want %dx%d × %d", got.Width, got.Height, got.Count(), want.Width, want.Height, want.Count())
+	}
+	if max(got.Depth, 1) != max(want.Depth, 1) {
+		t.Fatalf("depth %d, want %d", got.Depth, want.Depth)
+	}
+	for i, g := range want.Glyphs() {
+		if got.Code(i) != g.Code() {
+			t.Fatalf("glyph %d: code %d, want %d", i, got.Code(i), g.Code())
+		}
+		if !slices.Equal(got.Glyph(i).Levels(), g.Levels()) {
+			t.Fatalf("glyph %d: levels differ", i)
+		}
+		if got.Glyph(i).Advance() != g.Advance() || got.Glyph(i).Bearing() != g.Bearing() {
+			t.Fatalf("glyph %d: metrics %d/%d, want %d/%d", i,
+				got.Glyph(i).Bearing(), got.Glyph(i).Advance(), g.Bearing(), g.Advance())
+		}
+	}
+	if metrics && got.Metrics != want.Metrics {
+		t.Fatalf("metrics %+v, want %+v", got.Metrics, want.Metrics)
+	}
+	if got.Codepage != want.Codepage {
+		t.Fatalf("codepage %q, want %q", got.Codepage, want.Codepage)
+	}
+}
+
+// roundTrip zapisuje font w każdym formacie i porównuje go z fontem wczytanym z powrotem
+func roundTrip(t *testing.T, want *Font, opt exportOptions) {
+	t.Helper()
+	for _, format := range exportFormats {
+		t.Run(format, func(t *testing.T) {
+			src := generateFontSource(want, format, opt)
+			got, err := parseHeaderWithSize(strings.NewReader(src))
+			if err != nil {
+				t.Fatalf("%v\n%s", err, src)
+			}
+			sameFont(t, got, want, opt.Descriptor || format == formatPython)
+		})
+	}
+}
+
+// Zapis w każdym formacie i odczyt z powrotem dają ten sam font
+func TestExportImportRoundTrip(t *testing.T) {
+	for _, size := range [][2]int{{8, 8}, {5, 7}, {12, 10}, {16, 16}} {
+		t.Run(fmt.Sprintf("%dx%d", size[0], size[1]), func(t *testing.T) {
+			roundTrip(t, testFont(t, size[0], size[1], 1), exportOptions{})
+		})
+	}
+}
+
+// Tablice pisane ręcznie: wymiary z nazwy lub ze stałych, bajty składane w wiersze
+func TestParseFontSource(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		w, h int
+		want []uint16
+	}{
+		{"rust u8 10px", "pub static FONT_10x2: [u8; 4] = [0xFF, 0xC0, 0x80, 0x40];", 10, 2, []uint16{0x3FF, 0x201}},
+		{"rust u16 slice", "const GLYPH_WIDTH: usize = 3;\nconst GLYPH_HEIGHT: usize = 1;\nstatic GLYPHS: &[u16] = &[0x1, 0b101];", 3, 1, []uint16{1, 5}},
+		{"go byte", "var Font8x2 = []byte{0x81, 0x18}", 8, 2, []uint16{0x81, 0x18}},
+		{"python list", "FONT_8x2 = [0x81, 0x18]  # 0xFFFF", 8, 2, []uint16{0x81, 0x18}},
+		{"python wide values", "FONT_12x1 = [0x0ABC, 0x0001]", 12, 1, []uint16{0xABC, 1}},
+		{"python bytes", "WIDTH = 4\nHEIGHT = 2\nDATA = b'\\xf0\\x90'", 4, 2, []uint16{0xF, 0x9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nums, w, h, err := parseFontSource(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if w != tt.w || h != tt.h || !slices.Equal(nums, tt.want) {
+				t.Errorf("got %dx%d %X, want %dx%d %X", w, h, nums, tt.w, tt.h, tt.want)
+			}
+		})
+	}
+
+	// tablica bajtów bez wymiarów - nie da się złożyć wierszy
+	if _, _, _, err := parseFontSource("static GLYPHS: [u8; 2] = [0x81, 0x18];"); err == nil {
+		t.Error("byte array without size accepted")
+	}
+}
+
+func TestParseInitializer(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		want  []uint32
+		bytes bool
+	}{
+		{"hex", "{0x00, 0x1F,0xff}", []uint32{0, 0x1F, 0xFF}, false},
+		{"decimal and binary", "[12, 0b1010, 0B1]", []uint32{12, 10, 1}, false},
+		{"underscores and suffixes", "[0x00_FFu16, 0x18u8, 7usize]", []uint32{0xFF, 0x18, 7}, false},
+		{"identifiers skipped", "{ FONT_W8, 0x01, x2 }", []uint32{1}, false},
+		{"python bytes", `(b'\x00\x18A', b"\n")`, []uint32{0, 0x18, 'A', '\n'}, true},
+		{"empty", "{}", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, bytesLit, err := parseInitializer(tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) || bytesLit != tt.bytes {
+				t.Errorf("got %v (bytes %t), want %v (bytes %t)", got, bytesLit, tt.want, tt.bytes)
+			}
+		})
+	}
+}
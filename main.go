@@ -18,7 +18,8 @@
       • przesuwanie znaku w osi X/Y (shift) w oknie edycji,
       • aktualizację w czasie rzeczywistym widoczną w głównym podglądzie,
       • generowanie fragmentu kodu C dla edytowanego glifu,
-      • zapisywanie całej zmodyfikowanej tablicy jako pliku .h.
+      • zapisywanie całej zmodyfikowanej tablicy jako pliku .h,
+      • pracę na kilku fontach jednocześnie w zakładkach.
 
     Technologie:
       • GUI zbudowane w Fyne (Go)
@@ -28,7 +29,7 @@
 
     Uwagi:
       • Każdy wiersz znaku to jeden uint16 – bity odpowiadają pikselom.
-      • Edycja zapisuje zmiany bezpośrednio do fontData[].
+      • Edycja zapisuje zmiany bezpośrednio do Font.Data (model.go).
       • Obsługuje dowolny rozmiar czcionki (np. 5x8, 8x16, 16x16, 32x32…)
       • Zmiany są widoczne natychmiast w obu oknach.
 
@@ -47,13 +48,10 @@
 package main
 
 import (
-	"image/color"
-
-	"strconv"
+	"slices"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
-	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
@@ -61,22 +59,14 @@ import (
 
 // -- Zmienne globalne -------------------------------------------------------------------
 
-var versionApp = "1.0.4"      // wersja programu
-var editWin fyne.Window       // okno edycji znaku (referencja globalna)
-var editGrid *fyne.Container  // kontener z prostokątami w oknie edycji
-var sliderInternalUpdate bool // Flaga blokująca pushUndo podczas aktualizacji sliderów
-var xShift, yShift int        // globalne przesunięcia widoczne dla całego programu
-var langBtn *widget.Button    // zmienna dla przycisku języka
-var showGrid = true           // zmienna dla siatki w oknie edycji
-
-var rects [][]*canvas.Rectangle // prostokąty reprezentujące piksele w edycji
+var versionApp = "1.0.4"   // wersja programu
+var langBtn *widget.Button // zmienna dla przycisku języka
 
 func main() {
 
 	a := app.NewWithID("com.lothar-team.fontpreview") // identyfikator programu
 	w := a.NewWindow(" Font Preview v." + versionApp) // nazwa programu + nr wersji
 	w.Resize(fyne.NewSize(400, 750))                  // ustawienie początkowego rozmiaru
-	w.SetFixedSize(true)                              // blokada zmiany rozmiaru okna
 
 	// Załaduj ikonę z pliku
 	icon, err := fyne.LoadResourceFromPath("resources/AB256.png")
@@ -86,67 +76,65 @@ func main() {
 		w.SetIcon(icon)
 	}
 
-	currentIndex := 0 // aktualny indeks znaku
-	scale := 7        // początkowa skala powiększenia
-
-	loadedFileLabel := widget.NewLabel(T("noFile")) // wyświetlanie nazwy otwartego pliku
+	// Zakładki z fontami - każda ma własny font, skalę i historię UNDO
+	tabs := container.NewDocTabs()
+	var fontTabs []*fontTab
 
-	// Raster dynamiczny do wyświetlania znaku
-	imgRaster := canvas.NewRasterWithPixels(func(x, y, wR, hR int) color.Color {
-		if len(fontData) == 0 || glyphW == 0 || glyphH == 0 {
-			return color.White
+	// Zwraca zakładkę powiązaną z elementem DocTabs
+	tabOf := func(item *container.TabItem) *fontTab {
+		for _, t := range fontTabs {
+			if t.item == item {
+				return t
+			}
 		}
+		return nil
+	}
 
-		gx := x / scale
-		gy := y / scale
-
-		// sprawdzamy czy piksel mieści się w polu rysowania
-		if gx < 0 || gy < 0 || gx >= glyphW || gy >= glyphH {
-			return color.White
+	// Aktywna zakładka - na niej działają przyciski zapisu
+	activeTab := func() *fontTab {
+		return tabOf(tabs.Selected())
+	}
+	activeFont := func() *Font {
+		if t := activeTab(); t != nil {
+			return t.font
 		}
+		return nil
+	}
 
-		// --- NOWE: uwzględnienie przesunięcia ---
-		adjX := gx - xShift
-		adjY := gy - yShift
+	// Dodaje nową, pustą zakładkę
+	addTab := func() *fontTab {
+		t := newFontTab(w)
+		fontTabs = append(fontTabs, t)
+		return t
+	}
 
-		if adjX < 0 || adjY < 0 || adjX >= glyphW || adjY >= glyphH {
-			return color.White
+	tabs.CreateTab = func() *container.TabItem {
+		return addTab().item
+	}
+	tabs.OnClosed = func(item *container.TabItem) {
+		t := tabOf(item)
+		if t == nil {
+			return
 		}
-
-		row := fontData[currentIndex*glyphH+adjY]
-		bit := (row >> (glyphW - 1 - adjX)) & 1
-		if bit != 0 {
-			return color.Black
+		t.closeEditor()
+		fontTabs = slices.DeleteFunc(fontTabs, func(o *fontTab) bool { return o == t })
+		// zawsze zostaje przynajmniej jedna zakładka
+		if len(fontTabs) == 0 {
+			tabs.Append(addTab().item)
 		}
-		return color.White
-	})
-
-	imgRaster.SetMinSize(fyne.NewSize(float32(16*scale), float32(16*scale)))
-
-	// Etykieta pokazująca numer indeksu aktualnego znaku z tablicy
-	label := widget.NewLabel(T("glyph") + ": 0")
-
-	// Slider wyboru znaku
-	slider := widget.NewSlider(0, 0)
-	slider.Step = 1
-	slider.OnChanged = func(val float64) {
-		currentIndex = int(val)
-		label.SetText(T("glyph") + ": " + strconv.Itoa(currentIndex))
-		imgRaster.Refresh()
-		updateEditorGrid(currentIndex, imgRaster)
 	}
-
-	// Slider zmiany skali
-	scaleSlider := widget.NewSlider(1, 14)
-	scaleSlider.Value = float64(scale)
-	scaleLabel := widget.NewLabel(T("scale") + ": " + strconv.Itoa(scale))
-	scaleSlider.OnChanged = func(val float64) {
-		scale = int(val)
-		scaleLabel.SetText(T("scale") + ": " + strconv.Itoa(scale))
-		if glyphW > 0 && glyphH > 0 {
-			imgRaster.SetMinSize(fyne.NewSize(float32(glyphW*scale), float32(glyphH*scale)))
-			imgRaster.Refresh()
+	tabs.Append(addTab().item)
+
+	// Wczytany font trafia do aktywnej zakładki, jeśli jest pusta, w przeciwnym razie do nowej
+	openFont := func(f *Font, name string) {
+		t := activeTab()
+		if t == nil || !t.font.Empty() {
+			t = addTab()
+			tabs.Append(t.item)
+			tabs.Select(t.item)
 		}
+		t.setFont(f, name)
+		tabs.Refresh()
 	}
 
 	// Przycisk wczytywania pliku .h
@@ -155,35 +143,79 @@ func main() {
 			if rc == nil {
 				return
 			}
-			loadedFileLabel.SetText(T("loaded") + rc.URI().Name())
 			defer func() { _ = rc.Close() }()
-			nums, gw, gh, err := parseHeaderWithSize(rc)
+			f, err := parseHeaderWithSize(rc)
 			if err != nil {
 				dialog.ShowError(err, w)
 				return
 			}
+			// zapamiętanie oryginału dla "Zapisz z powrotem"
+			f.SourceURI = rc.URI()
+			openFont(f, rc.URI().Name())
+		}, w)
+	})
 
-			fontData = nums
-			glyphW = gw
-			glyphH = gh
+	// Przycisk importu ze zrzutu binarnego / Intel HEX
+	importBinBtn := widget.NewButton(T("importBinary"), func() {
+		importBinaryDialog(w, openFont)
+	})
 
-			slider.Max = float64(len(fontData)/glyphH - 1)
-			currentIndex = 0
-			slider.Value = 0
-			label.SetText(T("glyph") + ": 0")
-			imgRaster.SetMinSize(fyne.NewSize(float32(glyphW*scale), float32(glyphH*scale)))
-			imgRaster.Refresh()
-		}, w)
+	// Przycisk importu kolorowych ikon RGB565
+	importIconsBtn := widget.NewButton(T("importIcons"), func() {
+		iconImportDialog(w, openFont)
 	})
 
-	// Przycisk edycji znaku
-	editBtn := widget.NewButton(T("editGlyph"), func() {
-		openEditWindow(currentIndex, imgRaster)
+	// Przycisk kreatora nowego fontu
+	newFontBtn := widget.NewButton(T("newFont"), func() {
+		newFontDialog(w, openFont)
 	})
 
 	// Przycisk zapisu całego fontu
 	saveAllBtn := widget.NewButton(T("saveFont"), func() {
-		saveFontDialog(w)
+		saveFontDialog(w, activeFont())
+	})
+
+	// Przycisk zapisu zmian do oryginalnego pliku
+	saveBackBtn := widget.NewButton(T("saveBack"), func() {
+		saveBackDialog(w, activeFont())
+	})
+
+	// Przycisk podzbioru ze znaków użytych w źródłach firmware
+	sourceSubsetBtn := widget.NewButton(T("sourceSubset"), func() {
+		sourceSubsetDialog(w, activeFont())
+	})
+
+	// Przycisk panelu metryk fontu (linia bazowa, ascent, descent...)
+	metricsBtn := widget.NewButton(T("metrics"), func() {
+		if t := activeTab(); t != nil {
+			metricsDialog(w, t.font, t.metricsChanged)
+		}
+	})
+
+	// Przycisk zmiany rozmiaru komórki całego fontu
+	resizeCellBtn := widget.NewButton(T("resizeCell"), func() {
+		if t := activeTab(); t != nil {
+			resizeDialog(w, t.font, t.history, t.fontReshaped)
+		}
+	})
+
+	// Przycisk przekształceń zakresu glifów (odbicie, obrót, negatyw, przesunięcie)
+	transformBtn := widget.NewButton(T("transform"), func() {
+		if t := activeTab(); t != nil {
+			transformDialog(w, t.font, t.history, t.index, t.fontReshaped)
+		}
+	})
+
+	// Przycisk efektów (pogrubienie, pochylenie, kontur, cień) - wynik w nowej zakładce
+	effectsBtn := widget.NewButton(T("effects"), func() {
+		if t := activeTab(); t != nil {
+			effectsDialog(w, t.font, t.title(), openFont)
+		}
+	})
+
+	// Przycisk eksportu binarnego (.bin + nagłówek .h)
+	saveBinBtn := widget.NewButton(T("saveBinary"), func() {
+		saveBinaryDialog(w, activeFont())
 	})
 
 	// ---> przycisk zmiany jezyka PL/EN ---
@@ -195,30 +227,32 @@ func main() {
 			CurrentLang = "PL"
 			langBtn.SetText("🇬🇧")
 		}
-		updateMainTexts(btn, loadedFileLabel, label, editBtn, scaleLabel, saveAllBtn, currentIndex, scale)
+		updateMainTexts(btn, importBinBtn, importIconsBtn, newFontBtn, saveAllBtn, saveBackBtn, sourceSubsetBtn, saveBinBtn, metricsBtn, resizeCellBtn, transformBtn, effectsBtn)
+		for _, t := range fontTabs {
+			t.updateTexts()
+		}
+		tabs.Refresh()
 	})
 
 	// Układ GUI głównego okna
 	bottomBtns := container.NewVBox(
 		saveAllBtn,
+		saveBackBtn,
+		sourceSubsetBtn,
+		saveBinBtn,
+		metricsBtn,
+		resizeCellBtn,
+		transformBtn,
+		effectsBtn,
 		langBtn,
 	)
 
 	content := container.NewBorder(
-		nil,
+		container.NewVBox(btn, importBinBtn, importIconsBtn, newFontBtn),
 		bottomBtns,
 		nil,
 		nil,
-		container.NewVBox(
-			btn,
-			loadedFileLabel,
-			label,
-			slider,
-			editBtn,
-			scaleLabel,
-			scaleSlider,
-			container.NewCenter(imgRaster),
-		),
+		tabs,
 	)
 
 	w.SetContent(content)
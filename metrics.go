@@ -0,0 +1,204 @@
+/* ============================================================================
+
+    Metryki fontu
+    Linia bazowa, wydłużenie górne / dolne, wysokość x, wysokość wersalików
+    i odstęp między liniami – wspólne dla całego fontu, pozwalają wyrównać
+    napisy z różnych fontów
+    – EstimateMetrics, parseMetricValues, metricsDialog
+
+    Wszystkie wartości w pikselach. Baseline to numer wiersza komórki tuż
+    pod linią bazową (wiersze 0..Baseline-1 leżą nad nią); pozostałe
+    wysokości liczone są od linii bazowej.
+
+=========================================================================== */
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Metrics przechowuje metryki fontu
+type Metrics struct {
+	Baseline    int // wiersz pod linią bazową
+	Ascent      int // najwyższy tusz nad linią bazową
+	Descent     int // najniższy tusz pod linią bazową
+	XHeight     int // wysokość małych liter ('x')
+	CapHeight   int // wysokość wersalików ('H')
+	LineSpacing int // odległość między liniami tekstu
+}
+
+// Metryki zapisane w eksporcie: ".baseline = 12", "BASELINE = 12", "XHeight: 6"...
+var metricValueRE = regexp.MustCompile(`(?i)\b(?:\w*_)?(baseline|ascent|descent|x_?height|cap_?height|line_?spacing)\s*[:=]\s*(\d+)`)
+
+// metricNames to nazwy pól w kolejności panelu właściwości
+var metricNames = []string{"baseline", "ascent", "descent", "xHeight", "capHeight", "lineSpacing"}
+
+// field zwraca wskaźnik na pole metryki o podanej nazwie
+func (m *Metrics) field(name string) *int {
+	switch strings.ToLower(strings.ReplaceAll(name, "_", "")) {
+	case "baseline":
+		return &m.Baseline
+	case "ascent":
+		return &m.Ascent
+	case "descent":
+		return &m.Descent
+	case "xheight":
+		return &m.XHeight
+	case "capheight":
+		return &m.CapHeight
+	case "linespacing":
+		return &m.LineSpacing
+	}
+	return nil
+}
+
+// inkRows zwraca pierwszy i ostatni wiersz z zapalonym pikselem
+func (g Glyph) inkRows() (top, bottom int, ok bool) {
+	top = -1
+	for y, row := range g.Rows() {
+		if row != 0 {
+			if top < 0 {
+				top = y
+			}
+			bottom = y
+		}
+	}
+	return top, bottom, top >= 0
+}
+
+// inkTop zwraca najwyższy wiersz tuszu spośród podanych znaków (-1 = brak)
+func (f *Font) inkTop(chars string) int {
+	best := -1
+	for _, r := range chars {
+		if i := f.IndexOfRune(r); i >= 0 {
+			if top, _, ok := f.Glyph(i).inkRows(); ok && (best < 0 || top < best) {
+				best = top
+			}
+		}
+	}
+	return best
+}
+
+// inkBottom zwraca najniższy wiersz tuszu spośród podanych znaków (-1 = brak)
+func (f *Font) inkBottom(chars string) int {
+	best := -1
+	for _, r := range chars {
+		if i := f.IndexOfRune(r); i >= 0 {
+			if _, bottom, ok := f.Glyph(i).inkRows(); ok && bottom > best {
+				best = bottom
+			}
+		}
+	}
+	return best
+}
+
+// EstimateMetrics szacuje metryki z tuszu glifów: linia bazowa pod wersalikami
+// i cyframi, wysokość x z liter bez wydłużeń, ascent / descent ze wszystkich znaków
+func (f *Font) EstimateMetrics() {
+	m := Metrics{Baseline: f.Height, LineSpacing: f.Height}
+
+	// Znaki bez wydłużeń dolnych wyznaczają linię bazową
+	if bottom := f.inkBottom("HIELT0123456789xzvwunm"); bottom >= 0 {
+		m.Baseline = bottom + 1
+	} else {
+		// brak liter - dolna krawędź tuszu całego fontu
+		bottom := -1
+		for _, g := range f.Glyphs() {
+			if _, b, ok := g.inkRows(); ok && b > bottom {
+				bottom = b
+			}
+		}
+		if bottom >= 0 {
+			m.Baseline = bottom + 1
+		}
+	}
+
+	top, bottom := f.Height, -1
+	for _, g := range f.Glyphs() {
+		if t, b, ok := g.inkRows(); ok {
+			top, bottom = min(top, t), max(bottom, b)
+		}
+	}
+	if bottom >= 0 {
+		m.Ascent = m.Baseline - top
+		m.Descent = max(0, bottom+1-m.Baseline)
+	}
+
+	m.CapHeight = m.Ascent
+	if t := f.inkTop("HIEL"); t >= 0 {
+		m.CapHeight = m.Baseline - t
+	}
+	m.XHeight = m.CapHeight
+	if t := f.inkTop("xzvwunm"); t >= 0 {
+		m.XHeight = m.Baseline - t
+	}
+	if bottom >= 0 {
+		m.LineSpacing = m.Ascent + m.Descent + 1
+	}
+	f.Metrics = m
+}
+
+// parseMetricValues odczytuje metryki zapisane przy eksporcie (deskryptor lub stałe)
+func parseMetricValues(f *Font, src string) {
+	for _, m := range metricValueRE.FindAllStringSubmatch(src, -1) {
+		v, err := strconv.Atoi(m[2])
+		if p := f.Metrics.field(m[1]); p != nil && err == nil {
+			*p = v
+		}
+	}
+}
+
+// Wywoływane przy kliknięciu "Metryki"; onApply odświeża podglądy zakładki
+func metricsDialog(w fyne.Window, f *Font, onApply func()) {
+	if f.Empty() {
+		dialog.ShowInformation(T("noData"), T("loadFirst"), w)
+		return
+	}
+
+	entries := map[string]*widget.Entry{}
+	fill := func(m Metrics) {
+		for _, name := range metricNames {
+			entries[name].SetText(strconv.Itoa(*m.field(name)))
+		}
+	}
+
+	var items []*widget.FormItem
+	for _, name := range metricNames {
+		entries[name] = widget.NewEntry()
+		items = append(items, widget.NewFormItem(T("metric_"+name), entries[name]))
+	}
+	fill(f.Metrics)
+
+	// Ponowne oszacowanie z tuszu - tylko wypełnia pola, zapis po zatwierdzeniu
+	autoBtn := widget.NewButton(T("metricsAuto"), func() {
+		tmp := *f
+		tmp.EstimateMetrics()
+		fill(tmp.Metrics)
+	})
+	items = append(items, widget.NewFormItem("", autoBtn))
+
+	dialog.ShowForm(T("metrics"), T("saveAction"), T("cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		m := f.Metrics
+		for _, name := range metricNames {
+			v, err := strconv.Atoi(strings.TrimSpace(entries[name].Text))
+			if err != nil || v < 0 {
+				dialog.ShowError(fmt.Errorf("%s: %q", T("metricsBad"), entries[name].Text), w)
+				return
+			}
+			*m.field(name) = v
+		}
+		f.Metrics = m
+		onApply()
+	}, w)
+}
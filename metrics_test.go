@@ -0,0 +1,54 @@
+package main
+
+import "testing"
+
+// metricsFont tworzy font 5x8 ze znakami 'H' (wiersze 1-6), 'x' (3-6) i 'g' (3-7)
+func metricsFont() *Font {
+	f := NewFont(5, 8, []uint16{
+		0x00, 0x11, 0x11, 0x1F, 0x11, 0x11, 0x11, 0x00, // H
+		0x00, 0x00, 0x00, 0x0F, 0x11, 0x0F, 0x01, 0x0E, // g
+		0x00, 0x00, 0x00, 0x11, 0x0A, 0x0A, 0x11, 0x00, // x
+	})
+	f.Codes = []int{'H', 'g', 'x'}
+	return f
+}
+
+func TestEstimateMetrics(t *testing.T) {
+	f := metricsFont()
+	f.EstimateMetrics()
+	want := Metrics{Baseline: 7, Ascent: 6, Descent: 1, XHeight: 4, CapHeight: 6, LineSpacing: 8}
+	if f.Metrics != want {
+		t.Errorf("got %+v, want %+v", f.Metrics, want)
+	}
+
+	// bez liter - linia bazowa pod najniższym tuszem
+	f = NewFont(3, 6, []uint16{0, 2, 7, 2, 0, 0})
+	f.EstimateMetrics()
+	want = Metrics{Baseline: 4, Ascent: 3, Descent: 0, XHeight: 3, CapHeight: 3, LineSpacing: 4}
+	if f.Metrics != want {
+		t.Errorf("no letters: got %+v, want %+v", f.Metrics, want)
+	}
+}
+
+func TestParseMetricValues(t *testing.T) {
+	want := Metrics{Baseline: 12, Ascent: 11, Descent: 3, XHeight: 6, CapHeight: 9, LineSpacing: 16}
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"c descriptor", ".baseline = 12, .ascent = 11, .descent = 3, .xHeight = 6, .capHeight = 9, .lineSpacing = 16,"},
+		{"rust descriptor", "baseline: 12,\nascent: 11,\ndescent: 3,\nx_height: 6,\ncap_height: 9,\nline_spacing: 16,"},
+		{"python constants", "BASELINE = 12\nASCENT = 11\nDESCENT = 3\nX_HEIGHT = 6\nCAP_HEIGHT = 9\nLINE_SPACING = 16\n"},
+		{"go descriptor", "Baseline:      12,\nAscent: 11,\nDescent: 3,\nXHeight: 6,\nCapHeight: 9,\nLineSpacing: 16,"},
+		{"prefixed", "#define FONT_BASELINE = 12\nFONT_ASCENT = 11\nFONT_DESCENT = 3\nFONT_X_HEIGHT = 6\nFONT_CAP_HEIGHT = 9\nFONT_LINE_SPACING = 16"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := metricsFont()
+			parseMetricValues(f, tt.src)
+			if f.Metrics != want {
+				t.Errorf("got %+v, want %+v", f.Metrics, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/* ============================================================================
+
+    Model fontu
+    Typy Font i Glyph – cały stan fontu (wymiary, dane, metadane)
+    zamiast zmiennych globalnych; operują na nich parser, podgląd,
+    edytor, undo i zapis
+    – NewFont, Font.Glyph, Font.Glyphs, Glyph.Pixel / SetPixel
+
+    Każdy wiersz glifu to jeden uint16, bit (Width-1-x) to piksel x
+    (najstarszy używany bit po lewej stronie).
+
+=========================================================================== */
+
+package main
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+)
+
+// Domyślny kod pierwszego znaku w tablicy (font zaczyna się od spacji)
+const defaultFirstChar = 32
+
+// Font przechowuje bitmapowy font o stałym rozmiarze komórki
+type Font struct {
+	Width     int      // szerokość komórki w pikselach (max 16)
+	Height    int      // wysokość komórki w pikselach
+	FirstChar int      // kod znaku pierwszego glifu
+	Data      []uint16 // wiersze glifów, Height wierszy na glif
+	Codes     []int    // jawne kody znaków glifów (glyphs.go), nil = FirstChar + indeks
+	Codepage  string   // strona kodowa kodów glifów (codepage.go), "" = Unicode
+
+	// Font proporcjonalny (widths.go): szerokość i odstęp z lewej każdego glifu,
+	// nil = stała szerokość Width
+	Advance []int
+	Bearing []int
+
+	Metrics Metrics // linia bazowa, ascent, descent... (metrics.go)
+
+	// Odcienie szarości (gray.go) i ikony RGB565 (icon.go): bity na piksel
+	// (1, 2, 4, 16) i wartości pikseli, Pixels = nil dla fontu 1bpp
+	Depth  int
+	Pixels []uint16
+	Swap   bool // ikony RGB565 zapisane z zamienioną kolejnością bajtów
+
+	// Oryginalny plik źródłowy (dla "Zapisz z powrotem"), puste dla importu binarnego
+	SourceText string
+	SourceURI  fyne.URI
+}
+
+// NewFont tworzy font z gotowych wierszy glifów
+func NewFont(w, h int, data []uint16) *Font {
+	f := &Font{Width: w, Height: h, FirstChar: defaultFirstChar, Depth: 1, Data: data}
+	f.EstimateMetrics()
+	return f
+}
+
+// Clone zwraca głęboką kopię fontu (stan całego fontu dla UNDO)
+func (f *Font) Clone() *Font {
+	c := *f
+	c.Data = slices.Clone(f.Data)
+	c.Codes = slices.Clone(f.Codes)
+	c.Advance = slices.Clone(f.Advance)
+	c.Bearing = slices.Clone(f.Bearing)
+	c.Pixels = slices.Clone(f.Pixels)
+	return &c
+}
+
+// Empty zwraca true, jeśli font nie ma danych do wyświetlenia
+func (f *Font) Empty() bool {
+	return f == nil || len(f.Data) == 0 || f.Width == 0 || f.Height == 0
+}
+
+// Count zwraca liczbę glifów
+func (f *Font) Count() int {
+	if f == nil || f.Height == 0 {
+		return 0
+	}
+	return len(f.Data) / f.Height
+}
+
+// Glyph zwraca widok glifu o podanym indeksie
+func (f *Font) Glyph(i int) Glyph {
+	return Glyph{Font: f, Index: i}
+}
+
+// Glyphs pozwala iterować po wszystkich glifach: for i, g := range f.Glyphs()
+func (f *Font) Glyphs() iter.Seq2[int, Glyph] {
+	return func(yield func(int, Glyph) bool) {
+		for i := 0; i < f.Count(); i++ {
+			if !yield(i, f.Glyph(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Code zwraca kod znaku dla indeksu glifu
+func (f *Font) Code(i int) int {
+	if f.Codes != nil {
+		return f.Codes[i]
+	}
+	return f.FirstChar + i
+}
+
+// Index zwraca indeks glifu dla kodu znaku lub -1, jeśli font go nie zawiera
+func (f *Font) Index(code int) int {
+	if f.Codes != nil {
+		return slices.Index(f.Codes, code)
+	}
+	i := code - f.FirstChar
+	if i < 0 || i >= f.Count() {
+		return -1
+	}
+	return i
+}
+
+// BaseName zwraca bazową nazwę tablicy, np. "FONT_8x16" (ikony RGB565: "ICONS_16x16")
+func (f *Font) BaseName() string {
+	if f.RGB565() {
+		return "ICONS_" + strconv.Itoa(f.Width) + "x" + strconv.Itoa(f.Height)
+	}
+	return "FONT_" + strconv.Itoa(f.Width) + "x" + strconv.Itoa(f.Height)
+}
+
+// Mask zwraca maskę bitów wiersza o szerokości komórki
+func (f *Font) Mask() uint16 {
+	return uint16(1<<f.Width - 1)
+}
+
+// Glyph to widok jednego znaku w foncie - zmiany trafiają bezpośrednio do Font.Data
+type Glyph struct {
+	Font  *Font
+	Index int
+}
+
+// Rows zwraca wiersze glifu (wycinek danych fontu, bez kopii)
+func (g Glyph) Rows() []uint16 {
+	h := g.Font.Height
+	return g.Font.Data[g.Index*h : g.Index*h+h]
+}
+
+// Row zwraca wiersz y glifu
+func (g Glyph) Row(y int) uint16 {
+	return g.Font.Data[g.Index*g.Font.Height+y]
+}
+
+// SetRow ustawia wiersz y glifu
+func (g Glyph) SetRow(y int, row uint16) {
+	g.Font.Data[g.Index*g.Font.Height+y] = row & g.Font.Mask()
+}
+
+// Pixel zwraca stan piksela (x, y); poza komórką zawsze false
+func (g Glyph) Pixel(x, y int) bool {
+	f := g.Font
+	if x < 0 || y < 0 || x >= f.Width || y >= f.Height {
+		return false
+	}
+	return (g.Row(y)>>(f.Width-1-x))&1 != 0
+}
+
+// SetPixel zapala lub gasi piksel (x, y)
+func (g Glyph) SetPixel(x, y int, on bool) {
+	f := g.Font
+	if x < 0 || y < 0 || x >= f.Width || y >= f.Height {
+		return
+	}
+	bit := uint16(1) << (f.Width - 1 - x)
+	if on {
+		g.SetRow(y, g.Row(y)|bit)
+	} else {
+		g.SetRow(y, g.Row(y)&^bit)
+	}
+}
+
+// TogglePixel odwraca piksel (x, y) i zwraca jego nowy stan
+func (g Glyph) TogglePixel(x, y int) bool {
+	on := !g.Pixel(x, y)
+	g.SetPixel(x, y, on)
+	return on
+}
+
+// Code zwraca kod znaku glifu
+func (g Glyph) Code() int {
+	return g.Font.Code(g.Index)
+}
+
+// Label zwraca znak w apostrofach lub "" dla znaków niedrukowalnych
+// (ze stroną kodową fontu - znak Unicode, np. 'ą' dla 0xB9 w CP1250)
+func (g Glyph) Label() string {
+	r := g.Font.Rune(g.Code())
+	if r >= 32 && unicode.IsPrint(r) {
+		return fmt.Sprintf("'%c'", r)
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGlyphPixels(t *testing.T) {
+	f := NewFont(5, 2, make([]uint16, 4))
+	g := f.Glyph(1)
+	g.SetPixel(0, 0, true)
+	g.SetPixel(4, 1, true)
+	g.SetPixel(5, 0, true)  // poza komórką - bez zmian
+	g.SetPixel(-1, 1, true) // jw.
+	if !slices.Equal(f.Data, []uint16{0, 0, 0x10, 0x01}) {
+		t.Fatalf("data %02X", f.Data)
+	}
+	if !g.Pixel(0, 0) || g.Pixel(1, 0) || g.Pixel(5, 0) || f.Glyph(0).Pixel(0, 0) {
+		t.Error("Pixel reads wrong bits")
+	}
+	if g.TogglePixel(0, 0) || g.Row(0) != 0 {
+		t.Error("TogglePixel did not clear the pixel")
+	}
+	g.SetRow(1, 0xFFFF)
+	if g.Row(1) != 0x1F {
+		t.Errorf("SetRow kept bits outside the cell: %02X", g.Row(1))
+	}
+}
+
+func TestCodeIndex(t *testing.T) {
+	f := NewFont(3, 1, []uint16{1, 2, 3})
+	if f.Code(2) != defaultFirstChar+2 || f.Index(defaultFirstChar+1) != 1 || f.Index(defaultFirstChar+3) != -1 || f.Index(0) != -1 {
+		t.Error("contiguous codes")
+	}
+	f.Codes = []int{'A', 'Z', 0x104}
+	if f.Code(1) != 'Z' || f.Index(0x104) != 2 || f.Index('B') != -1 {
+		t.Error("code table")
+	}
+	if f.Glyph(0).Label() != "'A'" {
+		t.Errorf("label %q", f.Glyph(0).Label())
+	}
+}
+
+// Klon jest niezależny od oryginału (stan UNDO całego fontu)
+func TestFontClone(t *testing.T) {
+	f := testFont(t, 8, 8, 1)
+	f.SetProportional(true)
+	f.Codes = fontCodes(f)
+	c := f.Clone()
+	c.Glyph(0).SetRow(0, 0xFF)
+	c.Codes[0] = 0
+	c.Advance[0] = 1
+	if f.Glyph(0).Row(0) == 0xFF || f.Codes[0] == 0 || f.Advance[0] == 1 {
+		t.Error("clone shares slices with the original")
+	}
+}
+
+func TestFontEmptyCount(t *testing.T) {
+	var nilFont *Font
+	tests := []struct {
+		f     *Font
+		empty bool
+		count int
+	}{
+		{nilFont, true, 0},
+		{&Font{Width: 8}, true, 0},
+		{NewFont(8, 2, make([]uint16, 6)), false, 3},
+	}
+	for i, tt := range tests {
+		if tt.f.Empty() != tt.empty || tt.f.Count() != tt.count {
+			t.Errorf("%d: Empty %t Count %d", i, tt.f.Empty(), tt.f.Count())
+		}
+	}
+	if name := NewFont(12, 16, nil).BaseName(); name != "FONT_12x16" {
+		t.Errorf("BaseName %q", name)
+	}
+}
@@ -0,0 +1,198 @@
+/* ============================================================================
+
+    Nowy font
+    Kreator pustego fontu w pamięci: rozmiar komórki, zakres znaków
+    lub strona kodowa, układ bitów (głębia i kolejność bajtów RGB565)
+    i opcjonalny font bazowy (wbudowany 5x7 / 8x8 / 8x16, basefont.go)
+    – newFontFromTemplate, newFontDialog
+
+    Font bazowy wypełnia znaki ASCII; jeśli komórka ma inny rozmiar niż
+    font bazowy, glify są dopasowywane przez Font.Resize (lewy górny róg).
+
+=========================================================================== */
+
+package main
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Punkty wyjścia nowego fontu
+const (
+	baseBlank = "blank"
+	base5x7   = "5x7"
+	base8x8   = "8x8"
+	base8x16  = "8x16"
+)
+
+var baseFonts = []string{baseBlank, base5x7, base8x8, base8x16}
+
+// Największa wysokość komórki nowego fontu (także przy zmianie rozmiaru komórki)
+const maxCellHeight = 64
+
+// baseSize zwraca rozmiar komórki fontu bazowego
+func baseSize(base string) (int, int) {
+	switch base {
+	case base5x7:
+		return 5, 7
+	case base8x8:
+		return 8, 8
+	case base8x16:
+		return 8, 16
+	}
+	return 0, 0
+}
+
+// baseGlyph zwraca wiersze znaku z fontu bazowego w jego rozmiarze (nil = brak znaku)
+func baseGlyph(base string, r rune) []uint16 {
+	if base == baseBlank || r < ' ' || r > '~' {
+		return nil
+	}
+	var src []uint8
+	switch base {
+	case base5x7:
+		art := baseGlyphs5x8[r-' ']
+		// litery z wydłużeniem przesunięte o wiersz w górę
+		src = art[:7]
+		if art[7] != 0 {
+			src = art[1:]
+		}
+	case base8x8:
+		src = baseGlyphs8x8[r-' '][:]
+	case base8x16:
+		src = baseGlyphs8x16[r-' '][:]
+	}
+	rows := make([]uint16, len(src))
+	for i, v := range src {
+		rows[i] = uint16(v)
+	}
+	return rows
+}
+
+// bitLayout - układ bitów nowego fontu: bity na piksel i kolejność bajtów ikon RGB565
+type bitLayout struct {
+	Depth int
+	Swap  bool
+}
+
+// bitLayouts zwraca układy bitów do wyboru w kreatorze i ich nazwy
+// ("1 bpp", ..., "RGB565", "RGB565, zamienione bajty")
+func bitLayouts() ([]bitLayout, []string) {
+	names := depthOptions()
+	var layouts []bitLayout
+	for _, d := range depths {
+		layouts = append(layouts, bitLayout{Depth: d})
+	}
+	layouts = append(layouts, bitLayout{Depth: depthRGB565, Swap: true})
+	names = append(names, "RGB565, "+T("newFontSwapped"))
+	return layouts, names
+}
+
+// newFontFromTemplate tworzy font o podanych kodach znaków (ze stroną kodową cp lub Unicode),
+// wypełniony znakami fontu bazowego i przeliczony do wybranego układu bitów
+func newFontFromTemplate(w, h int, codes []int, cp, base string, layout bitLayout) *Font {
+	bw, bh := w, h
+	if base != baseBlank {
+		bw, bh = baseSize(base)
+	}
+	data := make([]uint16, len(codes)*bh)
+	for i, code := range codes {
+		copy(data[i*bh:(i+1)*bh], baseGlyph(base, codepageRune(cp, code)))
+	}
+
+	f := NewFont(bw, bh, data)
+	f.Codepage = cp
+	f.Codes = slices.Clone(codes)
+	f.compactCodes()
+	if bw != w || bh != h {
+		f.Resize(w, h, anchorTopLeft)
+	}
+	f.SetDepth(layout.Depth)
+	f.Swap = layout.Swap
+	return f
+}
+
+// Wywoływane przy kliknięciu "Nowy font"
+func newFontDialog(w fyne.Window, onCreated func(f *Font, name string)) {
+	widthEntry := widget.NewEntry()
+	widthEntry.SetText("8")
+	heightEntry := widget.NewEntry()
+	heightEntry.SetText("16")
+
+	// Znaki: zakres w polu tekstowym lub cała strona kodowa (32..255)
+	rangeEntry := widget.NewEntry()
+	rangeEntry.SetText("0x20-0x7E")
+	charsNames := append([]string{T("newFontRange")}, codepageNames...)
+	charsSelect := widget.NewSelect(charsNames, func(name string) {
+		if codepages[name] != nil {
+			rangeEntry.Disable()
+		} else {
+			rangeEntry.Enable()
+		}
+	})
+	charsSelect.SetSelected(charsNames[0])
+
+	layouts, layoutNames := bitLayouts()
+	layoutSelect := widget.NewSelect(layoutNames, nil)
+	layoutSelect.SetSelected(layoutNames[0])
+
+	// Font bazowy ustawia od razu swój rozmiar komórki
+	baseNames := make([]string, len(baseFonts))
+	for i, b := range baseFonts {
+		baseNames[i] = T("base_" + b)
+	}
+	baseSelect := widget.NewSelect(baseNames, func(name string) {
+		if bw, bh := baseSize(baseFonts[indexOf(baseNames, name)]); bw > 0 {
+			widthEntry.SetText(strconv.Itoa(bw))
+			heightEntry.SetText(strconv.Itoa(bh))
+		}
+	})
+	baseSelect.SetSelected(baseNames[0])
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(T("newFontBase"), baseSelect),
+		widget.NewFormItem(T("cellWidth"), widthEntry),
+		widget.NewFormItem(T("cellHeight"), heightEntry),
+		widget.NewFormItem(T("newFontChars"), charsSelect),
+		widget.NewFormItem("", rangeEntry),
+		widget.NewFormItem(T("newFontLayout"), layoutSelect),
+	}
+	dialog.ShowForm(T("newFont"), T("newFontCreate"), T("cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		nw, errW := strconv.Atoi(strings.TrimSpace(widthEntry.Text))
+		nh, errH := strconv.Atoi(strings.TrimSpace(heightEntry.Text))
+		if errW != nil || errH != nil || nw < 1 || nw > 16 || nh < 1 || nh > maxCellHeight {
+			dialog.ShowError(errors.New(T("cellSizeBad")), w)
+			return
+		}
+
+		cp := codepageFromOption(charsSelect.Selected)
+		var codes []int
+		if cp != "" {
+			for c := 0x20; c <= 0xFF; c++ {
+				codes = append(codes, c)
+			}
+		} else {
+			var err error
+			if codes, err = parseCharRange(rangeEntry.Text); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			slices.Sort(codes)
+			codes = slices.Compact(codes)
+		}
+
+		base := baseFonts[indexOf(baseNames, baseSelect.Selected)]
+		layout := layouts[indexOf(layoutNames, layoutSelect.Selected)]
+		onCreated(newFontFromTemplate(nw, nh, codes, cp, base, layout), "")
+	}, w)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBaseGlyph(t *testing.T) {
+	tests := []struct {
+		base string
+		r    rune
+		want []uint16
+	}{
+		{base5x7, 'H', []uint16{0x11, 0x11, 0x11, 0x1F, 0x11, 0x11, 0x11}},
+		{base8x8, 'H', []uint16{0xCC, 0xCC, 0xCC, 0xFC, 0xCC, 0xCC, 0xCC, 0x00}},
+		{base8x16, 'H', []uint16{0, 0, 0xC6, 0xC6, 0xC6, 0xC6, 0xFE, 0xC6, 0xC6, 0xC6, 0xC6, 0xC6, 0, 0, 0, 0}},
+		{base8x8, ' ', make([]uint16, 8)},
+		{base8x16, 'é', nil},
+		{baseBlank, 'H', nil},
+	}
+	for _, tt := range tests {
+		if got := baseGlyph(tt.base, tt.r); !slices.Equal(got, tt.want) {
+			t.Errorf("baseGlyph(%s, %q) = %02X, want %02X", tt.base, tt.r, got, tt.want)
+		}
+	}
+
+	// każdy znak ASCII fontu bazowego ma wiersze w rozmiarze fontu i mieści się w jego szerokości
+	for _, base := range baseFonts[1:] {
+		bw, bh := baseSize(base)
+		for r := ' '; r <= '~'; r++ {
+			rows := baseGlyph(base, r)
+			if len(rows) != bh || slices.ContainsFunc(rows, func(v uint16) bool { return v>>bw != 0 }) {
+				t.Errorf("%s %q: rows %02X do not fit %dx%d", base, r, rows, bw, bh)
+			}
+		}
+	}
+}
+
+func TestNewFontFromTemplate(t *testing.T) {
+	codes := []int{'A', 'B', 0xB9}
+	tests := []struct {
+		name   string
+		w, h   int
+		base   string
+		layout bitLayout
+	}{
+		{"blank 1bpp", 6, 9, baseBlank, bitLayout{Depth: 1}},
+		{"8x16 4bpp", 8, 16, base8x16, bitLayout{Depth: 4}},
+		{"8x8 resized", 10, 12, base8x8, bitLayout{Depth: 2}},
+		{"5x7 RGB565 swapped", 5, 7, base5x7, bitLayout{Depth: depthRGB565, Swap: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFontFromTemplate(tt.w, tt.h, codes, "CP1250", tt.base, tt.layout)
+			if f.Width != tt.w || f.Height != tt.h || f.Count() != len(codes) {
+				t.Fatalf("got %dx%d × %d", f.Width, f.Height, f.Count())
+			}
+			if max(f.Depth, 1) != tt.layout.Depth || f.Swap != tt.layout.Swap {
+				t.Errorf("depth %d swap %t, want %+v", f.Depth, f.Swap, tt.layout)
+			}
+			if f.Codepage != "CP1250" || !slices.Equal(fontCodes(f), codes) {
+				t.Errorf("codepage %q codes %v", f.Codepage, fontCodes(f))
+			}
+			// 'A' z fontu bazowego, 'ą' (0xB9 w CP1250) spoza ASCII - pusty
+			if _, _, ink := f.Glyph(0).InkBounds(); ink != (tt.base != baseBlank) {
+				t.Errorf("'A' ink %t", ink)
+			}
+			if _, _, ink := f.Glyph(2).InkBounds(); ink {
+				t.Error("'ą' has lit pixels")
+			}
+		})
+	}
+}
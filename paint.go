@@ -0,0 +1,218 @@
+/* ============================================================================
+
+    Malowanie myszą
+    Przezroczysta warstwa nad siatką pikseli okna edycji: wciśnięcie
+    i przeciąganie maluje wybranym narzędziem (tools.go), prawy
+    przycisk gumkuje
+    – paintCanvas, glyphEditor.paintStroke, linePoints
+
+    Lewy przycisk: tryb pociągnięcia wybiera pierwszy dotknięty piksel
+    (piksel już w kolorze rysowania – całe pociągnięcie gumkuje).
+    Całe pociągnięcie to jeden wpis UNDO, a punkty między kolejnymi
+    zdarzeniami myszy łączone są linią, żeby szybki ruch nie zostawiał
+    dziur.
+
+=========================================================================== */
+
+package main
+
+import (
+	"image"
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// linePoints wywołuje fn dla każdego piksela odcinka (x0, y0) – (x1, y1) (algorytm Bresenhama)
+func linePoints(x0, y0, x1, y1 int, fn func(x, y int)) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		fn(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// paintCanvas odbiera zdarzenia myszy nad siatką pikseli okna edycji
+type paintCanvas struct {
+	widget.BaseWidget
+	ed *glyphEditor
+
+	painting     bool   // trwa pociągnięcie
+	tool         string // narzędzie bieżącego pociągnięcia (tools.go)
+	level        int    // poziom rysowany w bieżącym pociągnięciu (0 = gumka)
+	startX       int    // początek kształtu (linia, prostokąt, elipsa)
+	startY       int
+	lastX, lastY int // ostatni piksel pociągnięcia
+
+	lifted *Font // przesuwany fragment zaznaczenia (clip.go), nil = nowe zaznaczenie
+}
+
+// newPaintCanvas tworzy warstwę malowania dla okna edycji
+func newPaintCanvas(ed *glyphEditor) *paintCanvas {
+	p := &paintCanvas{ed: ed}
+	p.ExtendBaseWidget(p)
+	return p
+}
+
+func (p *paintCanvas) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(canvas.NewRectangle(color.Transparent))
+}
+
+// cell zamienia pozycję kursora na piksel siatki
+func (p *paintCanvas) cell(pos fyne.Position) (int, int) {
+	return int(pos.X / p.ed.pixelSize), int(pos.Y / p.ed.pixelSize)
+}
+
+// MouseDown zaczyna pociągnięcie; tryb (rysowanie / gumka) ustala przycisk
+// i - dla ołówka - pierwszy piksel
+func (p *paintCanvas) MouseDown(e *desktop.MouseEvent) {
+	x, y := p.cell(e.Position)
+	p.tool = drawTool
+	p.level = p.ed.level
+	if e.Button == desktop.MouseButtonSecondary || (p.tool == toolPen && p.ed.glyph().Level(x, y) == p.level) {
+		p.level = 0
+	}
+	p.lifted = nil
+	switch {
+	case p.tool == toolSelect:
+		// wciśnięcie w zaznaczeniu przesuwa fragment, poza nim zaczyna nowe zaznaczenie
+		if image.Pt(x, y).In(p.ed.sel) {
+			p.lifted = copyRegion(p.ed.glyph(), p.ed.sel)
+		} else {
+			p.ed.setSelection(selectionSpan(x, y, x, y))
+		}
+	case p.tool == toolFill:
+		p.ed.floodFill(x, y, p.level)
+		return
+	case isShapeTool(p.tool):
+		p.ed.previewShape(p.tool, x, y, x, y, p.level)
+	default:
+		p.ed.paintStroke(x, y, x, y, p.level)
+	}
+	p.painting = true
+	p.startX, p.startY = x, y
+	p.lastX, p.lastY = x, y
+}
+
+func (p *paintCanvas) MouseUp(*desktop.MouseEvent) {
+	p.end()
+}
+
+// moveTo maluje odcinek od ostatniego piksela do piksela pod kursorem
+func (p *paintCanvas) moveTo(pos fyne.Position) {
+	if !p.painting {
+		return
+	}
+	x, y := p.cell(pos)
+	if x == p.lastX && y == p.lastY {
+		return
+	}
+	switch {
+	case p.tool == toolSelect && p.lifted != nil:
+		p.ed.previewMove(p.lifted, image.Pt(x-p.startX, y-p.startY))
+	case p.tool == toolSelect:
+		p.ed.setSelection(selectionSpan(p.startX, p.startY, x, y))
+	case isShapeTool(p.tool):
+		p.ed.previewShape(p.tool, p.startX, p.startY, x, y, p.level)
+	default:
+		p.ed.paintStroke(p.lastX, p.lastY, x, y, p.level)
+	}
+	p.lastX, p.lastY = x, y
+}
+
+// end kończy pociągnięcie (następne zacznie nowy wpis UNDO); kształt
+// lub przesunięty fragment z podglądu trafia do glifu
+func (p *paintCanvas) end() {
+	switch {
+	case !p.painting:
+	case p.tool == toolSelect && p.lifted != nil:
+		p.ed.moveSelection(p.lifted, image.Pt(p.lastX-p.startX, p.lastY-p.startY))
+	case p.tool == toolSelect && p.lastX == p.startX && p.lastY == p.startY:
+		// kliknięcie bez przeciągania usuwa zaznaczenie
+		p.ed.setSelection(image.Rectangle{})
+	case isShapeTool(p.tool):
+		p.ed.drawShape(p.tool, p.startX, p.startY, p.lastX, p.lastY, p.level)
+	}
+	p.painting = false
+	p.lifted = nil
+	p.ed.dragging = false
+}
+
+// Dragged - przeciąganie lewym przyciskiem
+func (p *paintCanvas) Dragged(e *fyne.DragEvent) {
+	p.moveTo(e.Position)
+}
+
+func (p *paintCanvas) DragEnd() {
+	p.end()
+}
+
+// MouseMoved - ruch z prawym przyciskiem (Fyne nie przeciąga prawym przyciskiem);
+// przycisk puszczony poza siatką kończy pociągnięcie
+func (p *paintCanvas) MouseMoved(e *desktop.MouseEvent) {
+	if e.Button == 0 {
+		p.end()
+		return
+	}
+	p.moveTo(e.Position)
+}
+
+func (p *paintCanvas) MouseIn(e *desktop.MouseEvent) {
+	p.MouseMoved(e)
+}
+
+func (p *paintCanvas) MouseOut() {}
+
+// Cursor pokazuje celownik nad siatką
+func (p *paintCanvas) Cursor() desktop.Cursor {
+	return desktop.CrosshairCursor
+}
+
+// paintStroke maluje odcinek pociągnięcia poziomem level; pierwsza zmiana
+// pociągnięcia zapisuje stan glifu do UNDO
+func (ed *glyphEditor) paintStroke(x0, y0, x1, y1, level int) {
+	f := ed.font
+	g := ed.glyph()
+	changed := false
+	linePoints(x0, y0, x1, y1, func(x, y int) {
+		if x < 0 || y < 0 || x >= f.Width || y >= f.Height || g.Level(x, y) == level {
+			return
+		}
+		ed.beginDrag()
+		g.SetLevel(x, y, level)
+		setRectColor(ed.rects[y][x], f, g.Level(x, y), !g.InAdvance(x))
+		changed = true
+	})
+	if changed {
+		ed.changed()
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"image"
+	"slices"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+)
+
+// testEditor otwiera okno edycji glifu 0 w aplikacji testowej Fyne
+// i zwraca je razem z warstwą malowania
+func testEditor(t *testing.T, f *Font) (*glyphEditor, *paintCanvas) {
+	t.Helper()
+	test.NewTempApp(t)
+	raster := canvas.NewRaster(func(w, h int) image.Image { return image.NewGray(image.Rect(0, 0, w, h)) })
+	ed := openEditWindow(f, &History{}, 0, raster)
+	tool := drawTool
+	t.Cleanup(func() { drawTool = tool })
+	return ed, newPaintCanvas(ed)
+}
+
+// cellPos zwraca środek piksela (x, y) siatki okna edycji
+func cellPos(ed *glyphEditor, x, y int) fyne.Position {
+	return fyne.NewPos((float32(x)+0.5)*ed.pixelSize, (float32(y)+0.5)*ed.pixelSize)
+}
+
+// stroke przeciąga lewym przyciskiem przez kolejne piksele i puszcza przycisk
+func stroke(ed *glyphEditor, p *paintCanvas, pts ...[2]int) {
+	p.MouseDown(&desktop.MouseEvent{PointEvent: fyne.PointEvent{Position: cellPos(ed, pts[0][0], pts[0][1])}, Button: desktop.MouseButtonPrimary})
+	for _, pt := range pts[1:] {
+		p.Dragged(&fyne.DragEvent{PointEvent: fyne.PointEvent{Position: cellPos(ed, pt[0], pt[1])}})
+	}
+	p.DragEnd()
+}
+
+func TestLinePoints(t *testing.T) {
+	tests := []struct {
+		name           string
+		x0, y0, x1, y1 int
+		want           [][2]int
+	}{
+		{"point", 2, 2, 2, 2, [][2]int{{2, 2}}},
+		{"horizontal back", 3, 0, 0, 0, [][2]int{{3, 0}, {2, 0}, {1, 0}, {0, 0}}},
+		{"diagonal", 0, 0, 2, 2, [][2]int{{0, 0}, {1, 1}, {2, 2}}},
+		{"steep", 0, 0, 1, 3, [][2]int{{0, 0}, {0, 1}, {1, 2}, {1, 3}}},
+	}
+	for _, tt := range tests {
+		var got [][2]int
+		linePoints(tt.x0, tt.y0, tt.x1, tt.y1, func(x, y int) { got = append(got, [2]int{x, y}) })
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Pociągnięcie ołówkiem łączy kolejne pozycje kursora odcinkami i jest jednym wpisem UNDO;
+// pociągnięcie zaczęte na zapalonym pikselu gasi
+func TestPaintStroke(t *testing.T) {
+	f := NewFont(5, 3, make([]uint16, 6))
+	ed, p := testEditor(t, f)
+	drawTool = toolPen
+
+	stroke(ed, p, [2]int{0, 0}, [2]int{3, 0}, [2]int{3, 2})
+	if !slices.Equal(f.Glyph(0).Rows(), []uint16{0b11110, 0b00010, 0b00010}) {
+		t.Fatalf("rows %05b", f.Glyph(0).Rows())
+	}
+	if len(ed.history.undoStack) != 1 {
+		t.Fatalf("%d undo entries, want 1", len(ed.history.undoStack))
+	}
+
+	stroke(ed, p, [2]int{3, 0}, [2]int{3, 2})
+	if !slices.Equal(f.Glyph(0).Rows(), []uint16{0b11100, 0, 0}) || len(ed.history.undoStack) != 2 {
+		t.Fatalf("erase stroke: rows %05b, %d undo entries", f.Glyph(0).Rows(), len(ed.history.undoStack))
+	}
+
+	ed.history.Undo(f, 0, 0)
+	ed.history.Undo(f, 0, 0)
+	if !slices.Equal(f.Glyph(0).Rows(), []uint16{0, 0, 0}) {
+		t.Errorf("after undo: rows %05b", f.Glyph(0).Rows())
+	}
+}
+
+// Prawy przycisk maluje tłem; puszczenie przycisku (ruch bez przycisku) kończy pociągnięcie
+func TestPaintStrokeSecondary(t *testing.T) {
+	f := NewGrayFont(3, 1, 2, levelsFrom("333"))
+	ed, p := testEditor(t, f)
+	drawTool = toolPen
+
+	right := func(x int, button desktop.MouseButton) *desktop.MouseEvent {
+		return &desktop.MouseEvent{PointEvent: fyne.PointEvent{Position: cellPos(ed, x, 0)}, Button: button}
+	}
+	p.MouseDown(right(0, desktop.MouseButtonSecondary))
+	p.MouseMoved(right(1, desktop.MouseButtonSecondary))
+	p.MouseMoved(right(2, 0))
+	if got := f.Glyph(0).Levels(); !slices.Equal(got, levelsFrom("003")) {
+		t.Errorf("levels %v", got)
+	}
+	if p.painting || ed.dragging || len(ed.history.undoStack) != 1 {
+		t.Errorf("stroke not finished: painting %t dragging %t undo %d", p.painting, ed.dragging, len(ed.history.undoStack))
+	}
+}
+
+// Pociągnięcie wychodzące poza siatkę nie zmienia pikseli poza komórką
+func TestPaintStrokeOutside(t *testing.T) {
+	f := NewFont(3, 3, make([]uint16, 3))
+	ed, p := testEditor(t, f)
+	drawTool = toolPen
+
+	stroke(ed, p, [2]int{1, 1}, [2]int{5, 1})
+	if !slices.Equal(f.Data, []uint16{0, 0b011, 0}) {
+		t.Errorf("rows %03b", f.Data)
+	}
+}
@@ -0,0 +1,166 @@
+/* ============================================================================
+
+    Zmiana rozmiaru komórki
+    Zmienia szerokość i wysokość wszystkich glifów fontu naraz
+    (np. 8x16 → 10x16), z wybranym punktem zaczepienia
+    – Font.Resize, Font.ResizeClipped, resizeDialog
+
+    Nowe kolumny / wiersze są puste, nadmiarowe są obcinane. Odstępy
+    z lewej, szerokości glifów i linia bazowa przesuwane są razem z
+    tuszem. Cała operacja to jeden wpis UNDO (History.PushFont).
+
+=========================================================================== */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Punkty zaczepienia zawartości przy zmianie rozmiaru komórki
+const (
+	anchorTopLeft  = "topLeft"  // lewy górny róg
+	anchorCenter   = "center"   // środek komórki
+	anchorBaseline = "baseline" // poziomo środek, pionowo linia bazowa na linii bazowej nowej komórki
+)
+
+var resizeAnchors = []string{anchorTopLeft, anchorCenter, anchorBaseline}
+
+// resizeOffset zwraca przesunięcie starej zawartości w nowej komórce w×h
+func (f *Font) resizeOffset(w, h int, anchor string) (dx, dy int) {
+	switch anchor {
+	case anchorCenter:
+		return (w - f.Width) / 2, (h - f.Height) / 2
+	case anchorBaseline:
+		return (w - f.Width) / 2, f.baselineIn(h) - f.Metrics.Baseline
+	}
+	return 0, 0
+}
+
+// baselineIn zwraca linię bazową komórki o wysokości h: wiersze nad i pod
+// linią bazową dzielone w tym samym stosunku co w obecnej komórce
+func (f *Font) baselineIn(h int) int {
+	return (f.Metrics.Baseline*h + f.Height/2) / f.Height
+}
+
+// ResizeClipped zwraca liczbę glifów, które po zmianie rozmiaru stracą zapalone piksele
+func (f *Font) ResizeClipped(w, h int, anchor string) int {
+	dx, dy := f.resizeOffset(w, h, anchor)
+	n := 0
+	for _, g := range f.Glyphs() {
+		left, right, ok := g.InkBounds()
+		if !ok {
+			continue
+		}
+		top, bottom, _ := g.inkRows()
+		if left+dx < 0 || right+dx >= w || top+dy < 0 || bottom+dy >= h {
+			n++
+		}
+	}
+	return n
+}
+
+// Resize zmienia rozmiar komórki wszystkich glifów, przesuwając tusz,
+// odstępy z lewej i metryki zgodnie z punktem zaczepienia
+func (f *Font) Resize(w, h int, anchor string) {
+	dx, dy := f.resizeOffset(w, h, anchor)
+	old := f.Clone()
+
+	f.Width, f.Height = w, h
+	f.Data = make([]uint16, old.Count()*h)
+	if old.Pixels != nil {
+		f.Pixels = make([]uint16, old.Count()*w*h)
+	}
+	for i, src := range old.Glyphs() {
+		dst := f.Glyph(i)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				if level := src.Level(x-dx, y-dy); level > 0 {
+					dst.SetLevel(x, y, level)
+				}
+			}
+		}
+	}
+
+	// Szerokości glifów przesuwane razem z tuszem (SetMetrics przycina do komórki)
+	if old.Proportional() {
+		for i, g := range f.Glyphs() {
+			bearing, advance := old.Bearing[i]+dx, old.Advance[i]
+			if bearing < 0 {
+				advance += bearing
+				bearing = 0
+			}
+			g.SetMetrics(bearing, advance)
+		}
+	}
+
+	m := &f.Metrics
+	m.Baseline = max(0, min(m.Baseline+dy, h))
+	m.Ascent = min(m.Ascent, m.Baseline)
+	m.CapHeight = min(m.CapHeight, m.Baseline)
+	m.XHeight = min(m.XHeight, m.Baseline)
+	m.Descent = min(m.Descent, h-m.Baseline)
+}
+
+// Wywoływane przy kliknięciu "Rozmiar komórki"; onApply odświeża zakładkę
+func resizeDialog(w fyne.Window, f *Font, history *History, onApply func()) {
+	if f.Empty() {
+		dialog.ShowInformation(T("noData"), T("loadFirst"), w)
+		return
+	}
+
+	widthEntry := widget.NewEntry()
+	widthEntry.SetText(strconv.Itoa(f.Width))
+	heightEntry := widget.NewEntry()
+	heightEntry.SetText(strconv.Itoa(f.Height))
+	anchorNames := make([]string, len(resizeAnchors))
+	for i, a := range resizeAnchors {
+		anchorNames[i] = T("anchor_" + a)
+	}
+	anchorSelect := widget.NewSelect(anchorNames, nil)
+	anchorSelect.SetSelected(anchorNames[0])
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(T("cellWidth"), widthEntry),
+		widget.NewFormItem(T("cellHeight"), heightEntry),
+		widget.NewFormItem(T("anchor"), anchorSelect),
+	}
+	dialog.ShowForm(T("resizeCell"), T("resizeApply"), T("cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		nw, errW := strconv.Atoi(strings.TrimSpace(widthEntry.Text))
+		nh, errH := strconv.Atoi(strings.TrimSpace(heightEntry.Text))
+		if errW != nil || errH != nil || nw < 1 || nw > 16 || nh < 1 || nh > maxCellHeight {
+			dialog.ShowError(errors.New(T("cellSizeBad")), w)
+			return
+		}
+		if nw == f.Width && nh == f.Height {
+			return
+		}
+		anchor := resizeAnchors[indexOf(anchorNames, anchorSelect.Selected)]
+
+		apply := func() {
+			history.PushFont(f)
+			f.Resize(nw, nh, anchor)
+			onApply()
+		}
+		// Ostrzeżenie przed obcięciem tuszu
+		if n := f.ResizeClipped(nw, nh, anchor); n > 0 {
+			dialog.ShowConfirm(T("resizeCell"), fmt.Sprintf(T("resizeClipWarn"), n), func(ok bool) {
+				if ok {
+					apply()
+				}
+			}, w)
+			return
+		}
+		apply()
+	}, w)
+}
@@ -0,0 +1,91 @@
+package main
+
+import "testing"
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name     string
+		w, h     int
+		anchor   string
+		x, y     int // nowa pozycja piksela (1, 1), poza komórką = obcięty
+		baseline int
+		bearing  int
+	}{
+		{"grow top-left", 6, 6, anchorTopLeft, 1, 1, 3, 1},
+		{"grow center", 6, 6, anchorCenter, 2, 2, 4, 2},
+		{"grow baseline", 6, 8, anchorBaseline, 2, 4, 6, 2},
+		{"shrink top-left", 2, 2, anchorTopLeft, 1, 1, 2, 1},
+		{"shrink baseline", 4, 2, anchorBaseline, 1, 0, 2, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewGrayFont(4, 4, 2, make([]uint16, 16))
+			f.Glyph(0).SetLevel(1, 1, 2)
+			f.Glyph(0).SetMetrics(1, 2)
+			f.Metrics = Metrics{Baseline: 3, Ascent: 3, Descent: 1, XHeight: 2, CapHeight: 3, LineSpacing: 4}
+
+			f.Resize(tt.w, tt.h, tt.anchor)
+			if f.Width != tt.w || f.Height != tt.h || len(f.Data) != tt.h || len(f.Pixels) != tt.w*tt.h {
+				t.Fatalf("got %dx%d (%d rows, %d pixels)", f.Width, f.Height, len(f.Data), len(f.Pixels))
+			}
+			g := f.Glyph(0)
+			for y := 0; y < f.Height; y++ {
+				for x := 0; x < f.Width; x++ {
+					want := 0
+					if x == tt.x && y == tt.y {
+						want = 2
+					}
+					if g.Level(x, y) != want {
+						t.Errorf("level(%d, %d) = %d, want %d", x, y, g.Level(x, y), want)
+					}
+				}
+			}
+			if f.Metrics.Baseline != tt.baseline || f.Metrics.Ascent > f.Metrics.Baseline ||
+				f.Metrics.Descent > f.Height-f.Metrics.Baseline {
+				t.Errorf("metrics %+v, want baseline %d", f.Metrics, tt.baseline)
+			}
+			if g.Bearing() != tt.bearing {
+				t.Errorf("bearing %d, want %d", g.Bearing(), tt.bearing)
+			}
+		})
+	}
+}
+
+func TestResizeClipped(t *testing.T) {
+	f := NewFont(4, 4, []uint16{
+		0x0, 0x4, 0x0, 0x0, // piksel (1, 1)
+		0x0, 0x0, 0x0, 0x1, // piksel (3, 3)
+		0x0, 0x0, 0x0, 0x0, // pusty
+	})
+	tests := []struct {
+		w, h   int
+		anchor string
+		want   int
+	}{
+		{6, 6, anchorCenter, 0},
+		{2, 2, anchorTopLeft, 1},
+		{2, 2, anchorCenter, 1},
+		{1, 1, anchorTopLeft, 2},
+		{4, 3, anchorBaseline, 0},
+		{4, 2, anchorBaseline, 1},
+	}
+	for _, tt := range tests {
+		if got := f.ResizeClipped(tt.w, tt.h, tt.anchor); got != tt.want {
+			t.Errorf("ResizeClipped(%d, %d, %s) = %d, want %d", tt.w, tt.h, tt.anchor, got, tt.want)
+		}
+	}
+}
+
+// Zmiana rozmiaru jest jednym wpisem UNDO całego fontu (wymiary, piksele, szerokości i metryki)
+func TestResizeUndo(t *testing.T) {
+	f := testFont(t, 5, 7, 1)
+	f.SetProportional(true)
+	want := f.Clone()
+	h := &History{}
+	h.PushFont(f)
+	f.Resize(8, 10, anchorBaseline)
+	if _, _, ok := h.Undo(f, 0, 0); !ok {
+		t.Fatal("nothing to undo")
+	}
+	sameFont(t, f, want, true)
+}
@@ -0,0 +1,276 @@
+/* ============================================================================
+
+    Zapis z powrotem do oryginalnego pliku
+    Zamiast generować nowy plik, podmienia tylko wartości liczbowe
+    w inicjalizatorze oryginalnej tablicy – nazwa, kwalifikatory,
+    komentarze i formatowanie pozostają bez zmian (czysty diff w git).
+    Rozmiar komórki i głębia fontu muszą zgadzać się z nazwą tablicy
+    i stałymi w pliku (checkSourceShape).
+    – rewriteSource, saveBackDialog
+
+=========================================================================== */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+)
+
+// numberSpans zwraca pozycje liczb w inicjalizatorze zaczynającym się na pozycji open.
+// Pomija komentarze i napisy; hasBytes = true, jeśli w tablicy są literały bytes.
+func numberSpans(src string, open int, python bool) (spans [][2]int, hasBytes bool) {
+	depth := 0
+	for i := open; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '\'' || c == '"':
+			if i > 0 && (src[i-1] == 'b' || src[i-1] == 'B') {
+				hasBytes = true
+			}
+			j := i + 1
+			for j < len(src) && src[j] != c {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			i = j
+			if depth == 0 {
+				return spans, hasBytes
+			}
+		case python && c == '#', !python && c == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case !python && c == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return spans, hasBytes
+			}
+			i += end + 3
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+			if depth <= 0 {
+				return spans, hasBytes
+			}
+		case c >= '0' && c <= '9':
+			m := strings.TrimRight(numberRE.FindString(src[i:]), "_")
+			spans = append(spans, [2]int{i, i + len(m)})
+			i += len(m)
+			for i < len(src) && isIdentByte(src[i]) {
+				i++
+			}
+			i--
+		case isIdentByte(c):
+			for i < len(src) && isIdentByte(src[i]) {
+				i++
+			}
+			i--
+		}
+	}
+	return spans, hasBytes
+}
+
+// arrayOpen zwraca nazwę tablicy fontu, pozycję nawiasu otwierającego jej inicjalizator
+// oraz szerokość elementu w bitach (0 = lista Pythona, wnioskowana z wartości)
+func arrayOpen(src string) (name string, open, bits int, python bool, err error) {
+	if m := rustDeclRE.FindStringSubmatchIndex(src); m != nil {
+		bits = 16
+		if src[m[4]:m[5]] == "u8" {
+			bits = 8
+		}
+		return src[m[2]:m[3]], m[1] - 1, bits, false, nil
+	}
+	if m := goDeclRE.FindStringSubmatchIndex(src); m != nil {
+		bits = 16
+		if src[m[4]:m[5]] != "uint16" {
+			bits = 8
+		}
+		return src[m[2]:m[3]], m[1] - 1, bits, false, nil
+	}
+	if open, ok := cArrayOpen(src); ok {
+		return cDeclRE.FindStringSubmatch(src)[1], open, 16, false, nil
+	}
+	if m := pyDeclRE.FindStringSubmatchIndex(src); m != nil {
+		return src[m[2]:m[3]], m[1] - 1, 0, true, nil
+	}
+	return "", 0, 0, false, errors.New(T("saveBackNoArray"))
+}
+
+// checkSourceShape sprawdza, czy rozmiar komórki i głębia fontu zgadzają się
+// z nazwą tablicy i stałymi w pliku. Po zmianie rozmiaru komórki lub głębi
+// podmiana samych wartości dałaby tablicę opisaną starym rozmiarem.
+func checkSourceShape(f *Font, src, name string) error {
+	nw, nh := sizeFromName(name)
+	mw, mh := sizeFromMeta(src)
+	for _, size := range [][2]int{{nw, f.Width}, {nh, f.Height}, {mw, f.Width}, {mh, f.Height}} {
+		if size[0] > 0 && size[0] != size[1] {
+			return fmt.Errorf(T("saveBackSize"), max(nw, mw), max(nh, mh), f.Width, f.Height)
+		}
+	}
+	depth := bppFromSource(src)
+	if _, ok := iconSwapFromSource(src); ok {
+		depth = depthRGB565
+	}
+	if depth != max(f.Depth, 1) {
+		return fmt.Errorf(T("saveBackDepth"), depth, max(f.Depth, 1))
+	}
+	return nil
+}
+
+// formatLike formatuje wartość w tym samym stylu co oryginalny token
+// (prefiks, liczba cyfr, wielkość liter)
+func formatLike(orig string, v uint32, lower bool) string {
+	switch {
+	case len(orig) > 2 && (orig[1] == 'x' || orig[1] == 'X'):
+		digits := len(strings.ReplaceAll(orig[2:], "_", ""))
+		if lower {
+			return orig[:2] + fmt.Sprintf("%0*x", digits, v)
+		}
+		return orig[:2] + fmt.Sprintf("%0*X", digits, v)
+	case len(orig) > 2 && (orig[1] == 'b' || orig[1] == 'B'):
+		digits := len(strings.ReplaceAll(orig[2:], "_", ""))
+		return orig[:2] + fmt.Sprintf("%0*b", digits, v)
+	}
+	return fmt.Sprintf("%d", v)
+}
+
+// rewriteSource podmienia wartości w inicjalizatorze oryginalnej tablicy na dane fontu
+func rewriteSource(f *Font, src string) (string, error) {
+	name, open, bits, python, err := arrayOpen(src)
+	if m := cByteDeclRE.FindStringSubmatchIndex(src); f.Grayscale() && m != nil {
+		// tablica C uint8_t z upakowanymi odcieniami szarości
+		name, open, bits, python, err = src[m[2]:m[3]], m[1]-1, 8, false, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := checkSourceShape(f, src, name); err != nil {
+		return "", err
+	}
+	spans, hasBytes := numberSpans(src, open, python)
+	if hasBytes {
+		return "", errors.New(T("saveBackBytes"))
+	}
+
+	// Styl liter w liczbach hex: decyduje większość w pliku
+	lowerCount, upperCount := 0, 0
+	for _, sp := range spans {
+		for _, c := range src[sp[0]+min(2, sp[1]-sp[0]) : sp[1]] {
+			if c >= 'a' && c <= 'f' {
+				lowerCount++
+			} else if c >= 'A' && c <= 'F' {
+				upperCount++
+			}
+		}
+	}
+	lower := lowerCount > upperCount
+
+	if bits == 0 {
+		// lista Pythona - 16 bitów, jeśli jakakolwiek wartość nie mieści się w bajcie
+		bits = 8
+		values, _, err := parseInitializer(initializerBody(src, open, true))
+		if err != nil {
+			return "", err
+		}
+		for _, v := range values {
+			if v > 0xFF {
+				bits = 16
+				break
+			}
+		}
+	}
+
+	// Nowe wartości w kolejności tokenów
+	var values []uint32
+	if f.RGB565() {
+		// ikony RGB565 - wartości 16-bitowe w kolejności bajtów pliku
+		for _, g := range f.Glyphs() {
+			for _, v := range exportPixels(g) {
+				values = append(values, uint32(v))
+			}
+		}
+	} else if f.Depth > 1 {
+		// upakowane odcienie szarości - zawsze bajty
+		for _, g := range f.Glyphs() {
+			for _, b := range packGray(g) {
+				values = append(values, uint32(b))
+			}
+		}
+	} else if bits == 16 {
+		for _, row := range f.Data {
+			values = append(values, uint32(row))
+		}
+	} else {
+		bytesPerRow := (f.Width + 7) / 8
+		pad := bytesPerRow*8 - f.Width
+		for _, row := range f.Data {
+			v := uint32(row) << pad
+			for b := bytesPerRow - 1; b >= 0; b-- {
+				values = append(values, (v>>(8*b))&0xFF)
+			}
+		}
+	}
+	if len(values) != len(spans) {
+		return "", fmt.Errorf(T("saveBackCount"), len(spans), len(values))
+	}
+
+	var sb strings.Builder
+	last := 0
+	for i, sp := range spans {
+		sb.WriteString(src[last:sp[0]])
+		orig := src[sp[0]:sp[1]]
+		if parsed, _, err := parseInitializer(orig); err == nil && len(parsed) == 1 && parsed[0] == values[i] {
+			sb.WriteString(orig) // wartość bez zmian - zostawiamy token co do znaku
+		} else {
+			sb.WriteString(formatLike(orig, values[i], lower))
+		}
+		last = sp[1]
+	}
+	sb.WriteString(src[last:])
+	return sb.String(), nil
+}
+
+// Wywoływane przy kliknięciu "Zapisz z powrotem"
+func saveBackDialog(w fyne.Window, f *Font) {
+	if f.Empty() {
+		dialog.ShowInformation(T("noData"), T("loadFirst"), w)
+		return
+	}
+	if f.SourceURI == nil || f.SourceText == "" {
+		dialog.ShowInformation(T("saveBack"), T("saveBackNoSource"), w)
+		return
+	}
+
+	out, err := rewriteSource(f, f.SourceText)
+	if err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+
+	dialog.ShowConfirm(T("saveBack"), fmt.Sprintf(T("saveBackConfirm"), f.SourceURI.Name()), func(ok bool) {
+		if !ok {
+			return
+		}
+		wc, err := storage.Writer(f.SourceURI)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		defer func() { _ = wc.Close() }()
+		if _, err := wc.Write([]byte(out)); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		f.SourceText = out
+		dialog.ShowInformation(T("saved"), T("saved"), w)
+	}, w)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Zapis z powrotem bez zmian w foncie zostawia plik co do znaku,
+// a zmieniony glif podmienia tylko swoje wartości w stylu oryginału
+func TestRewriteSource(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string // wynik po zapaleniu piksela (0, 0) pierwszego glifu
+	}{
+		{"c",
+			"#define FONT_H 0x03 // 0xFF\n" +
+				"/* header 0x1234 */\n" +
+				"static const uint16_t FONT_2x3[] = {\n" +
+				"    0x0000, 0x0001,0x0002,   // ' '  0x20\n" +
+				"    0x03,   0x0003, 0x0,     /* '!' */\n" +
+				"};\n",
+			"#define FONT_H 0x03 // 0xFF\n" +
+				"/* header 0x1234 */\n" +
+				"static const uint16_t FONT_2x3[] = {\n" +
+				"    0x0002, 0x0001,0x0002,   // ' '  0x20\n" +
+				"    0x03,   0x0003, 0x0,     /* '!' */\n" +
+				"};\n"},
+		{"c lowercase hex",
+			"const uint16_t F_2x3[] = { 0x00, 0xa, 0x1, 0x0, 0x0, 0x0 };\n",
+			"const uint16_t F_2x3[] = { 0x02, 0xa, 0x1, 0x0, 0x0, 0x0 };\n"},
+		{"rust",
+			"pub static FONT_2X3: [u16; 6] = [\n    0x0000, 0x0001, 0x0002, // 1\n    3, 0b11, 0x0000_u16,\n];\n",
+			"pub static FONT_2X3: [u16; 6] = [\n    0x0002, 0x0001, 0x0002, // 1\n    3, 0b11, 0x0000_u16,\n];\n"},
+		{"go",
+			"var Font2x3 = []uint16{\n\t0x0000, 0x0001, 0x0002, // 0x99\n\t0x0003, 0x0003, 0x0000,\n}\n",
+			"var Font2x3 = []uint16{\n\t0x0002, 0x0001, 0x0002, // 0x99\n\t0x0003, 0x0003, 0x0000,\n}\n"},
+		{"python",
+			"WIDTH = 2\nFONT_2X3 = [\n    0x00, 0x40, 0x80,  # 0x00\n    0xC0, 0xC0, 0x00,\n]\n",
+			"WIDTH = 2\nFONT_2X3 = [\n    0x80, 0x40, 0x80,  # 0x00\n    0xC0, 0xC0, 0x00,\n]\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := parseHeaderWithSize(strings.NewReader(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if f.Width != 2 || f.Height != 3 || f.Count() != 2 {
+				t.Fatalf("loaded %dx%d × %d, want 2x3 × 2", f.Width, f.Height, f.Count())
+			}
+
+			out, err := rewriteSource(f, tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out != tt.src {
+				t.Errorf("unchanged font rewrote the file:\n%s", out)
+			}
+
+			f.Glyph(0).SetPixel(0, 0, true)
+			if out, err = rewriteSource(f, tt.src); err != nil {
+				t.Fatal(err)
+			}
+			if out != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteSourceErrors(t *testing.T) {
+	f := NewFont(2, 3, []uint16{0, 1, 2})
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"no array", "#define A 0x01\n"},
+		{"count mismatch", "const uint16_t F_2x3[] = { 0x00, 0x01 };\n"},
+		{"python bytes", "DATA = (\n    b'\\x00\\x01\\x02',\n)\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := rewriteSource(f, tt.src); err == nil {
+				t.Errorf("rewriteSource(%q): expected error", tt.src)
+			}
+		})
+	}
+}
+
+// Zmiana rozmiaru komórki lub głębi przy tej samej liczbie wartości: zapis odmówiony,
+// bo nazwa tablicy i stałe w pliku opisywałyby stary font
+func TestRewriteSourceShapeMismatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		change func(f *Font)
+	}{
+		{"resized, size in name",
+			"const uint16_t F_2x3[] = { 0x0, 0x1, 0x2, 0x3, 0x3, 0x0 };\n",
+			func(f *Font) { f.Resize(3, 3, anchorTopLeft) }},
+		{"resized, size in constants",
+			"WIDTH = 2\nHEIGHT = 3\nDATA = [\n    0x00, 0x40, 0x80, 0xC0, 0xC0, 0x00,\n]\n",
+			func(f *Font) { f.Resize(3, 3, anchorTopLeft) }},
+		{"depth changed",
+			"const uint16_t F_2x3[] = { 0x0, 0x1, 0x2, 0x3, 0x3, 0x0 };\n",
+			func(f *Font) { f.SetDepth(2) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := parseHeaderWithSize(strings.NewReader(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.change(f)
+			if _, err := rewriteSource(f, tt.src); err == nil {
+				t.Errorf("rewriteSource after change: expected error")
+			}
+		})
+	}
+}
+
+// Plik świeżo zapisany w każdym formacie da się zapisać z powrotem bez zmian
+// (MicroPython zapisuje literały bytes, których zapis z powrotem nie obsługuje)
+func TestRewriteSourceExported(t *testing.T) {
+	fonts := map[string]*Font{
+		"1bpp":   testFont(t, 8, 16, 1),
+		"4bpp":   testFont(t, 6, 8, 4),
+		"RGB565": NewIconFont(4, 3, make([]uint16, 24), true),
+	}
+	for name, f := range fonts {
+		for _, format := range []string{formatC, formatRust, formatGo} {
+			for _, opt := range []exportOptions{{}, {Art: true, Descriptor: true, DescriptorType: "FontDef"}} {
+				src := generateFontSource(f, format, opt)
+				loaded, err := parseHeaderWithSize(strings.NewReader(src))
+				if err != nil {
+					t.Fatalf("%s %s: %v", name, format, err)
+				}
+				if out, err := rewriteSource(loaded, src); err != nil || out != src {
+					t.Errorf("%s %s descriptor=%t: %v", name, format, opt.Descriptor, err)
+				}
+			}
+		}
+	}
+}
+
+// Plik C: liczby tylko z inicjalizatora tablicy, tej samej, którą podmienia zapis
+// z powrotem (#define, komentarze i kolejne tablice pomijane)
+func TestParseCHex(t *testing.T) {
+	src := "#define FONT_W 0x08\n" +
+		"/* 0xDEAD */\n" +
+		"const uint16_t FONT_2x3[] = {\n" +
+		"   0x0001,0x0002,0x0003,  // 0x41 'A'\n" +
+		"   0x0000,0x0003,0x0001,  /* 0x42 */\n" +
+		"};\n" +
+		"const uint16_t FONT_2x3_codes[] = { 0x41, 0x42 };\n"
+	nums, gw, gh, err := parseCHex(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []uint16{1, 2, 3, 0, 3, 1}
+	if gw != 2 || gh != 3 || !slices.Equal(nums, want) {
+		t.Errorf("got %dx%d %v, want 2x3 %v", gw, gh, nums, want)
+	}
+
+	// plik bez deklaracji tablicy - wszystkie liczby hex
+	nums, _, _, err = parseCHex("0x18, 0x24,\n0x7E")
+	if err != nil || !slices.Equal(nums, []uint16{0x18, 0x24, 0x7E}) {
+		t.Errorf("bare hex: got %X, %v", nums, err)
+	}
+}
@@ -0,0 +1,259 @@
+/* ============================================================================
+
+    Podzbiór ze źródeł firmware
+    Zbiera wszystkie znaki użyte w literałach napisów w katalogu ze
+    źródłami C/C++ (lub w pliku z tłumaczeniami) i przygotowuje eksport
+    fontu zawierającego tylko te glify + zestaw zawsze dołączanych znaków
+    – collectLiteralChars, scanSourcePath, sourceSubsetDialog
+
+=========================================================================== */
+
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Pliki, z których brane są tylko literały napisów
+var literalExts = map[string]bool{
+	".c": true, ".h": true, ".cpp": true, ".hpp": true, ".cc": true, ".hh": true,
+	".cxx": true, ".ino": true, ".po": true, ".pot": true, ".json": true,
+}
+
+// Pliki tekstowe z komunikatami - liczą się wszystkie znaki
+var textExts = map[string]bool{
+	".txt": true, ".csv": true, ".properties": true, ".ini": true, ".strings": true,
+}
+
+// Znaki zawsze dołączane do podzbioru (edytowalne w oknie)
+var alwaysInclude = " 0123456789"
+
+// collectLiteralChars zwraca znaki ze wszystkich literałów "..." i '...' w kodzie C/C++.
+// Komentarze są pomijane, sekwencje ucieczki dekodowane.
+func collectLiteralChars(src string, set map[rune]bool) {
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return
+			}
+			i += end + 3
+		case c == '\'' && i > 0 && isIdentByte(src[i-1]):
+			// separator cyfr C++14 (1'000) lub apostrof w identyfikatorze
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(src) && src[j] != c && src[j] != '\n' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j > len(src) {
+				j = len(src)
+			}
+			// #include <...> i "..." w dyrektywach to ścieżki, nie napisy
+			lineStart := strings.LastIndexByte(src[:i], '\n') + 1
+			if !strings.HasPrefix(strings.TrimSpace(src[lineStart:i]), "#") {
+				for _, r := range unescapeC(src[i+1 : j]) {
+					set[r] = true
+				}
+			}
+			i = j
+		}
+	}
+}
+
+// unescapeC dekoduje sekwencje ucieczki C (\n, \", \\, \xHH, \ooo, \uXXXX)
+func unescapeC(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'r', 't', 'a', 'b', 'f', 'v':
+			// znaki sterujące nie mają glifów
+		case 'x':
+			j := i + 1
+			for j < len(s) && j < i+3 && strings.IndexByte("0123456789abcdefABCDEF", s[j]) >= 0 {
+				j++
+			}
+			if v, err := strconv.ParseUint(s[i+1:j], 16, 8); err == nil {
+				sb.WriteRune(rune(v))
+			}
+			i = j - 1
+		case 'u', 'U':
+			n := 4
+			if s[i] == 'U' {
+				n = 8
+			}
+			if i+1+n <= len(s) {
+				if v, err := strconv.ParseUint(s[i+1:i+1+n], 16, 32); err == nil {
+					sb.WriteRune(rune(v))
+				}
+				i += n
+			}
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			j := i
+			for j < len(s) && j < i+3 && s[j] >= '0' && s[j] <= '7' {
+				j++
+			}
+			if v, err := strconv.ParseUint(s[i:j], 8, 8); err == nil && v != 0 {
+				sb.WriteRune(rune(v))
+			}
+			i = j - 1
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
+
+// scanSourcePath zbiera znaki z pliku lub rekurencyjnie z katalogu.
+// Zwraca zbiór znaków i liczbę przeczytanych plików.
+func scanSourcePath(path string) (map[rune]bool, int, error) {
+	set := map[rune]bool{}
+	files := 0
+
+	scanFile := func(p string) error {
+		ext := strings.ToLower(filepath.Ext(p))
+		if !literalExts[ext] && !textExts[ext] {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files++
+		if textExts[ext] {
+			for _, r := range charsOfText(string(data)) {
+				set[rune(r)] = true
+			}
+			return nil
+		}
+		collectLiteralChars(string(data), set)
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !info.IsDir() {
+		return set, files, scanFile(path)
+	}
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// pomijamy ukryte katalogi (.git, .vscode...)
+			if p != path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		return scanFile(p)
+	})
+	return set, files, err
+}
+
+// sortedChars zwraca znaki zbioru jako posortowany napis
+func sortedChars(set map[rune]bool) string {
+	runes := make([]rune, 0, len(set))
+	for r := range set {
+		if r >= 32 {
+			runes = append(runes, r)
+		}
+	}
+	sort.Slice(runes, func(a, b int) bool { return runes[a] < runes[b] })
+	return string(runes)
+}
+
+// Wywoływane przy kliknięciu "Podzbiór ze źródeł"
+func sourceSubsetDialog(w fyne.Window, f *Font) {
+	if f.Empty() {
+		dialog.ShowInformation(T("noData"), T("loadFirst"), w)
+		return
+	}
+
+	pathLabel := widget.NewLabel(T("noFile"))
+	var path string
+
+	folderBtn := widget.NewButton(T("scanFolder"), func() {
+		dialog.ShowFolderOpen(func(lu fyne.ListableURI, _ error) {
+			if lu == nil {
+				return
+			}
+			path = lu.Path()
+			pathLabel.SetText(path)
+		}, w)
+	})
+	fileBtn := widget.NewButton(T("scanFile"), func() {
+		dialog.ShowFileOpen(func(rc fyne.URIReadCloser, _ error) {
+			if rc == nil {
+				return
+			}
+			_ = rc.Close()
+			path = rc.URI().Path()
+			pathLabel.SetText(path)
+		}, w)
+	})
+
+	includeEntry := widget.NewEntry()
+	includeEntry.SetText(alwaysInclude)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(T("scanSource"), container.NewHBox(folderBtn, fileBtn)),
+		widget.NewFormItem("", pathLabel),
+		widget.NewFormItem(T("alwaysInclude"), includeEntry),
+	}
+
+	dialog.ShowForm(T("sourceSubset"), T("scanAction"), T("cancel"), items, func(ok bool) {
+		if !ok || path == "" {
+			return
+		}
+		alwaysInclude = includeEntry.Text
+
+		set, files, err := scanSourcePath(path)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		for _, r := range alwaysInclude {
+			set[r] = true
+		}
+		chars := sortedChars(set)
+
+		// Raport: znaki znalezione i brakujące w foncie
+		_, missing := subsetIndices(f, charsOfText(chars))
+		msg := fmt.Sprintf(T("scanReport"), files, len([]rune(chars)))
+		if len(missing) > 0 {
+			msg += "\n" + T("subsetMissing") + " " + strconv.Quote(string(missing))
+		}
+		dialog.ShowConfirm(T("sourceSubset"), msg+"\n\n"+T("scanContinue"), func(ok bool) {
+			if ok {
+				showSaveFontForm(w, f, chars)
+			}
+		}, w)
+	}, w)
+}
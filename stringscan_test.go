@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCollectLiteralChars(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"strings and chars", `lcd_puts("Hi"); putc('!');`, "!Hi"},
+		{"comments skipped", "// \"XY\"\n/* \"Z\" */ show(\"ab\");", "ab"},
+		{"escaped quote", `msg("a\"b");`, `"ab`},
+		{"include paths skipped", "#include \"lcd.h\"\n#include <stdio.h>\nx = \"q\";", "q"},
+		{"digit separator", "n = 1'000; s = \"k\";", "k"},
+		{"utf-8", `title = "Żółw";`, "Żółw"},
+	}
+	for _, tt := range tests {
+		set := map[rune]bool{}
+		collectLiteralChars(tt.src, set)
+		if got := sortedChars(set); got != sortedChars(runeSet(tt.want)) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUnescapeC(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{`a\nb\t`, "ab"},
+		{`\"\\\'`, `"\'`},
+		{`\x41\x4a`, "AJ"},
+		{`\101\0`, "A"},
+		{`Ą\U0001F600`, "Ą😀"},
+	}
+	for _, tt := range tests {
+		if got := unescapeC(tt.in); got != tt.want {
+			t.Errorf("unescapeC(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// Katalog: literały z plików C, wszystkie znaki z plików tekstowych, inne rozszerzenia pomijane
+func TestScanSourcePath(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.c":           `show("AB"); // "Z"`,
+		"ui/menu.hpp":      `const char *m = "c";`,
+		"lang/pl.txt":      "ę",
+		"build/firmware.o": `"Q"`,
+	}
+	for name, src := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	set, n, err := scanSourcePath(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sortedChars(set); n != 3 || got != "ABcę" {
+		t.Errorf("got %q from %d files, want \"ABcę\" from 3", got, n)
+	}
+}
+
+// runeSet zwraca zbiór znaków napisu
+func runeSet(s string) map[rune]bool {
+	set := map[rune]bool{}
+	for _, r := range s {
+		set[r] = true
+	}
+	return set
+}
@@ -0,0 +1,188 @@
+/* ============================================================================
+
+    Eksport podzbioru znaków
+    Wybór glifów do zapisu: zakres ("0-9", "A-Z", "0x20-0x7E"), jawny
+    zestaw znaków lub znaki użyte w podanym tekście
+    – parseCharRange, charsOfText, subsetIndices, exportIndices
+
+    Jeśli wybrane znaki nie tworzą ciągłego zakresu, eksport dopisuje
+    tabelę kodów znaków (codes) odpowiadającą kolejnym glifom.
+
+=========================================================================== */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Tryby wyboru znaków w oknie zapisu
+const (
+	subsetAll   = "all"
+	subsetRange = "range"
+	subsetSet   = "set"
+	subsetText  = "text"
+)
+
+var subsetModes = []string{subsetAll, subsetRange, subsetSet, subsetText}
+
+// exportIndices zwraca indeksy glifów do zapisu
+func exportIndices(f *Font, opt exportOptions) []int {
+	if opt.Glyphs != nil {
+		return opt.Glyphs
+	}
+	idx := make([]int, f.Count())
+	for i := range idx {
+		idx[i] = i
+	}
+	return idx
+}
+
+// exportFirstChar zwraca kod pierwszego zapisywanego znaku
+func exportFirstChar(f *Font, opt exportOptions) int {
+	idx := exportIndices(f, opt)
+	if len(idx) == 0 {
+		return f.FirstChar
+	}
+	return f.Code(idx[0])
+}
+
+// exportContiguous sprawdza, czy zapisywane znaki tworzą ciągły zakres kodów
+func exportContiguous(f *Font, opt exportOptions) bool {
+	idx := exportIndices(f, opt)
+	for n := 1; n < len(idx); n++ {
+		if f.Code(idx[n]) != f.Code(idx[n-1])+1 {
+			return false
+		}
+	}
+	return true
+}
+
+// exportCodes zwraca kody zapisywanych znaków w kolejności glifów
+func exportCodes(f *Font, opt exportOptions) []int {
+	idx := exportIndices(f, opt)
+	codes := make([]int, len(idx))
+	for n, i := range idx {
+		codes[n] = f.Code(i)
+	}
+	return codes
+}
+
+// joinInts łączy liczby przecinkami (tabela kodów)
+func joinInts(vals []int) string {
+	parts := make([]string, len(vals))
+	for i, v := range vals {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Największy kod znaku w zakresie (tabele kodów eksportu są uint16)
+const maxCharCode = 0xFFFF
+
+// parseCharCode odczytuje jeden koniec zakresu: pojedynczy znak lub liczbę (np. 65, 0x41)
+func parseCharCode(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	v := int64(-1)
+	if utf8.RuneCountInString(s) == 1 {
+		r, _ := utf8.DecodeRuneInString(s)
+		v = int64(r)
+	} else if n, err := strconv.ParseInt(s, 0, 64); err == nil {
+		v = n
+	} else {
+		return 0, fmt.Errorf("%s: %q", T("subsetBadRange"), s)
+	}
+	if v < 0 || v > maxCharCode {
+		return 0, fmt.Errorf(T("subsetCodeMax"), s, maxCharCode)
+	}
+	return int(v), nil
+}
+
+// parseCharRange odczytuje listę zakresów rozdzieloną przecinkami, np. "0-9, A-Z, 0x20"
+func parseCharRange(s string) ([]int, error) {
+	var codes []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		// "-" jako separator, ale nie dla pojedynczego znaku "-"
+		from, to := part, part
+		if i := strings.Index(part[1:], "-"); i >= 0 {
+			from, to = part[:i+1], part[i+2:]
+		}
+		a, err := parseCharCode(from)
+		if err != nil {
+			return nil, err
+		}
+		b, err := parseCharCode(to)
+		if err != nil {
+			return nil, err
+		}
+		if b < a {
+			a, b = b, a
+		}
+		for c := a; c <= b; c++ {
+			codes = append(codes, c)
+		}
+	}
+	if len(codes) == 0 {
+		return nil, errors.New(T("subsetEmpty"))
+	}
+	return codes, nil
+}
+
+// charsOfText zwraca kody wszystkich znaków użytych w tekście (bez znaków końca linii)
+func charsOfText(s string) []int {
+	var codes []int
+	for _, r := range s {
+		if r == '\n' || r == '\r' {
+			continue
+		}
+		codes = append(codes, int(r))
+	}
+	return codes
+}
+
+// subsetIndices zamienia kody znaków na posortowane, unikalne indeksy glifów.
+// Znaki, których font nie zawiera, zwracane są jako missing.
+func subsetIndices(f *Font, codes []int) (indices []int, missing []rune) {
+	seen := map[int]bool{}
+	missSeen := map[int]bool{}
+	for _, c := range codes {
+		i := f.IndexOfRune(rune(c))
+		if i < 0 {
+			if !missSeen[c] {
+				missSeen[c] = true
+				missing = append(missing, rune(c))
+			}
+			continue
+		}
+		if !seen[i] {
+			seen[i] = true
+			indices = append(indices, i)
+		}
+	}
+	sort.Ints(indices)
+	return indices, missing
+}
+
+// subsetReport opisuje wynik eksportu podzbioru: liczba znaków i zaoszczędzone bajty
+func subsetReport(f *Font, opt exportOptions, missing []rune) string {
+	total := f.Count()
+	kept := len(exportIndices(f, opt))
+	saved := (total - kept) * glyphBytes(f)
+	if !exportContiguous(f, opt) {
+		saved -= 2 * kept // tabela kodów uint16
+	}
+	msg := fmt.Sprintf(T("subsetReport"), kept, total, saved)
+	if len(missing) > 0 {
+		msg += "\n" + T("subsetMissing") + " " + strconv.Quote(string(missing))
+	}
+	return msg
+}
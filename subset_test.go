@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseCharRange(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []int
+	}{
+		{"A", []int{'A'}},
+		{"0-3", []int{'0', '1', '2', '3'}},
+		{"C-A", []int{'A', 'B', 'C'}},
+		{"0x20-0x22", []int{0x20, 0x21, 0x22}},
+		{"65-67", []int{'A', 'B', 'C'}},
+		{"a-b, X, 0x30", []int{'a', 'b', 'X', '0'}},
+		{"-", []int{'-'}},
+		{"ą-ć", []int{'ą', 'Ć', 'ć'}},
+		{" , 7 ,", []int{'7'}},
+	}
+	for _, tt := range tests {
+		got, err := parseCharRange(tt.in)
+		if err != nil {
+			t.Errorf("parseCharRange(%q): %v", tt.in, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("parseCharRange(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", " , ", "AB", "0x-1", "A-0xZZ", "0-0x7FFFFFFF", "0x10000", "😀"} {
+		if _, err := parseCharRange(bad); err == nil {
+			t.Errorf("parseCharRange(%q): expected error", bad)
+		}
+	}
+}
+
+// Zaoszczędzone bajty liczone rozmiarem glifu w eksporcie (1bpp, odcienie szarości, RGB565)
+func TestSubsetReport(t *testing.T) {
+	tests := []struct {
+		name  string
+		font  *Font
+		saved int
+	}{
+		{"1bpp", NewFont(6, 4, make([]uint16, 4*4)), 3 * 2 * 4},
+		{"4bpp", NewGrayFont(6, 4, 4, make([]uint16, 4*6*4)), 3 * 3 * 4},
+		{"RGB565", NewIconFont(6, 4, make([]uint16, 4*6*4), false), 3 * 2 * 6 * 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := subsetReport(tt.font, exportOptions{Glyphs: []int{0}}, nil)
+			if want := fmt.Sprintf(T("subsetReport"), 1, 4, tt.saved); !strings.HasPrefix(msg, want) {
+				t.Errorf("got %q, want %q", msg, want)
+			}
+		})
+	}
+}
+
+// Zapis podzbioru: tylko wybrane glify, z tabelą kodów dla nieciągłego zakresu
+func TestExportSubsetRoundTrip(t *testing.T) {
+	f := testFont(t, 8, 8, 1)
+	indices, missing := subsetIndices(f, charsOfText("ACE"))
+	if len(missing) != 0 {
+		t.Fatalf("missing %q", missing)
+	}
+	for _, format := range exportFormats {
+		src := generateFontSource(f, format, exportOptions{Glyphs: indices})
+		got, err := parseHeaderWithSize(strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if got.Count() != 3 {
+			t.Fatalf("%s: %d glyphs, want 3", format, got.Count())
+		}
+		for n, i := range indices {
+			if got.Code(n) != f.Code(i) || !slices.Equal(got.Glyph(n).Rows(), f.Glyph(i).Rows()) {
+				t.Errorf("%s: glyph %d differs from %q", format, n, rune(f.Code(i)))
+			}
+		}
+	}
+}
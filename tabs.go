@@ -0,0 +1,503 @@
+/* ============================================================================
+
+    Zakładki fontów
+    Każda zakładka głównego okna ma własny font, wybrany znak, skalę
+    podglądu, historię UNDO / REDO, okno edycji i nazwę pliku
+    – newFontTab, fontTab.setFont, fontTab.updateTexts, fontTab.glyphOp
+
+    Przyciski zapisu i edycji działają zawsze na aktywnej zakładce.
+
+=========================================================================== */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"slices"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// fontTab przechowuje stan jednej zakładki
+type fontTab struct {
+	font     *Font
+	history  *History     // UNDO / REDO edycji
+	editor   *glyphEditor // otwarte okno edycji (nil = brak)
+	index    int          // aktualny indeks znaku
+	scale    int          // skala powiększenia podglądu
+	fileName string       // nazwa wczytanego pliku ("" = brak)
+	win      fyne.Window  // główne okno (komunikaty błędów)
+
+	item       *container.TabItem
+	fileLabel  *widget.Label
+	glyphLabel *widget.Label
+	scaleLabel *widget.Label
+	slider     *widget.Slider
+	editBtn    *widget.Button
+	raster     *canvas.Raster
+
+	// Schowek całych glifów (clip.go) - także między zakładkami
+	copyGlyphBtn  *widget.Button
+	pasteGlyphBtn *widget.Button
+
+	// UNDO / REDO operacji na całym foncie (zamykają okno edycji)
+	undoBtn *widget.Button
+	redoBtn *widget.Button
+
+	// Lista glifów: wstawianie, duplikowanie, usuwanie, przesuwanie, kod znaku
+	insertBtn     *widget.Button
+	duplicateBtn  *widget.Button
+	deleteBtn     *widget.Button
+	codeLabel     *widget.Label
+	codeEntry     *widget.Entry
+	setCodeBtn    *widget.Button
+	renumberCheck *widget.Check
+
+	// Strona kodowa: interpretacja kodów glifów i konwersja układu
+	codepageLabel  *widget.Label
+	codepageSelect *widget.Select
+	convertBtn     *widget.Button
+
+	// Głębia bitowa fontu (1 / 2 / 4 bpp, RGB565) i zamiana bajtów ikon
+	depthSelect *widget.Select
+	depthLabel  *widget.Label
+	swapCheck   *widget.Check
+
+	// Font proporcjonalny: przełącznik i podgląd przykładowego napisu
+	propCheck    *widget.Check
+	sampleEntry  *widget.Entry
+	sampleRaster *canvas.Raster
+	samplePlaced []placedGlyph
+}
+
+// Skala podglądu przykładowego napisu
+const sampleScale = 2
+
+// newFontTab tworzy pustą zakładkę z podglądem znaku
+func newFontTab(w fyne.Window) *fontTab {
+	t := &fontTab{font: &Font{}, scale: 7, win: w}
+	t.resetHistory()
+
+	t.fileLabel = widget.NewLabel(T("noFile")) // wyświetlanie nazwy otwartego pliku
+
+	// Raster dynamiczny do wyświetlania znaku
+	t.raster = canvas.NewRasterWithPixels(func(x, y, wR, hR int) color.Color {
+		if t.font.Empty() {
+			return color.White
+		}
+
+		gx := x / t.scale
+		gy := y / t.scale
+
+		// uwzględnienie przesunięcia z otwartego okna edycji
+		if t.editor.isOpen() && t.editor.index == t.index {
+			gx -= t.editor.xShift
+			gy -= t.editor.yShift
+		}
+
+		g := t.font.Glyph(t.index)
+		inCell := gx >= 0 && gx < t.font.Width && gy >= 0 && gy < t.font.Height
+		// ikony RGB565: czarne tło (0x0000) jest częścią obrazka
+		if level := g.Level(gx, gy); level > 0 || t.font.RGB565() && inCell {
+			return t.font.PixelColor(level)
+		}
+		// kolumny poza szerokością glifu proporcjonalnego
+		if inCell && !g.InAdvance(gx) {
+			return color.Gray{Y: 220}
+		}
+		return color.White
+	})
+	t.raster.SetMinSize(fyne.NewSize(float32(16*t.scale), float32(16*t.scale)))
+
+	// Etykieta pokazująca numer indeksu aktualnego znaku z tablicy
+	t.glyphLabel = widget.NewLabel(T("glyph") + ": 0")
+
+	// Slider wyboru znaku
+	t.slider = widget.NewSlider(0, 0)
+	t.slider.Step = 1
+	t.slider.OnChanged = func(val float64) {
+		t.index = int(val)
+		t.updateGlyphInfo()
+		t.raster.Refresh()
+		updateEditorGrid(t.editor, t.index)
+	}
+
+	// Slider zmiany skali
+	scaleSlider := widget.NewSlider(1, 14)
+	scaleSlider.Value = float64(t.scale)
+	t.scaleLabel = widget.NewLabel(T("scale") + ": " + strconv.Itoa(t.scale))
+	scaleSlider.OnChanged = func(val float64) {
+		t.scale = int(val)
+		t.scaleLabel.SetText(T("scale") + ": " + strconv.Itoa(t.scale))
+		if !t.font.Empty() {
+			t.raster.SetMinSize(fyne.NewSize(float32(t.font.Width*t.scale), float32(t.font.Height*t.scale)))
+			t.raster.Refresh()
+		}
+	}
+
+	// Przycisk edycji znaku
+	t.editBtn = widget.NewButton(T("editGlyph"), func() {
+		t.editor = openEditWindow(t.font, t.history, t.index, t.raster)
+		if t.editor != nil {
+			t.editor.onChange = t.refreshSample
+		}
+	})
+
+	// Kopiowanie / wklejanie całego glifu - wklejenie to jeden wpis UNDO glifu
+	t.copyGlyphBtn = widget.NewButton(T("glyphCopy"), func() {
+		if !t.font.Empty() {
+			g := t.font.Glyph(t.index)
+			glyphClipboard = copyRegion(g, glyphBounds(t.font))
+		}
+	})
+	t.pasteGlyphBtn = widget.NewButton(T("glyphPaste"), func() {
+		if t.font.Empty() {
+			return
+		}
+		if glyphClipboard == nil {
+			dialog.ShowInformation(T("glyphPaste"), T("clipboardEmpty"), t.win)
+			return
+		}
+		g := t.font.Glyph(t.index)
+		t.history.Push(g, 0, 0)
+		pasteGlyph(glyphClipboard, g)
+		if t.editor.isOpen() && t.editor.index == t.index {
+			t.editor.refreshGrid()
+		}
+		t.raster.Refresh()
+		t.refreshSample()
+	})
+
+	// Cofanie / ponawianie z głównego okna - także zmian całego fontu
+	t.undoBtn = widget.NewButton(T("undo"), func() {
+		t.closeEditor()
+		if _, _, ok := t.history.Undo(t.font, 0, 0); ok {
+			t.refresh()
+		}
+	})
+	t.redoBtn = widget.NewButton(T("redo"), func() {
+		t.closeEditor()
+		if _, _, ok := t.history.Redo(t.font, 0, 0); ok {
+			t.refresh()
+		}
+	})
+
+	// Operacje na liście glifów - każda to jeden wpis UNDO całego fontu
+	t.insertBtn = widget.NewButton(T("glyphInsert"), func() {
+		t.glyphOp(t.index+1, func() { t.font.InsertGlyph(t.index+1, t.renumberCheck.Checked) })
+	})
+	t.duplicateBtn = widget.NewButton(T("glyphDuplicate"), func() {
+		t.glyphOp(t.index+1, func() { t.font.DuplicateGlyph(t.index, t.renumberCheck.Checked) })
+	})
+	t.deleteBtn = widget.NewButton(T("glyphDelete"), func() {
+		if t.font.Count() <= 1 {
+			dialog.ShowError(errors.New(T("glyphLastDelete")), t.win)
+			return
+		}
+		t.glyphOp(t.index, func() { _ = t.font.DeleteGlyph(t.index, t.renumberCheck.Checked) })
+	})
+	moveLeftBtn := widget.NewButton("◀", func() {
+		if t.index > 0 {
+			t.glyphOp(t.index-1, func() { t.font.MoveGlyph(t.index, t.index-1, t.renumberCheck.Checked) })
+		}
+	})
+	moveRightBtn := widget.NewButton("▶", func() {
+		if t.index < t.font.Count()-1 {
+			t.glyphOp(t.index+1, func() { t.font.MoveGlyph(t.index, t.index+1, t.renumberCheck.Checked) })
+		}
+	})
+	t.renumberCheck = widget.NewCheck(T("glyphRenumber"), nil)
+
+	// Kod znaku aktualnego glifu (znak lub liczba, np. "A", 65, 0x41)
+	t.codeLabel = widget.NewLabel(T("glyphCode"))
+	t.codeEntry = widget.NewEntry()
+	t.setCodeBtn = widget.NewButton(T("glyphSetCode"), func() {
+		if t.font.Empty() {
+			return
+		}
+		code, err := parseCharCode(t.codeEntry.Text)
+		if err == nil {
+			if j := t.font.Index(code); j >= 0 && j != t.index {
+				err = fmt.Errorf(T("codeUsed"), code, j)
+			}
+		}
+		if err != nil {
+			dialog.ShowError(err, t.win)
+			return
+		}
+		t.glyphOp(t.index, func() { _ = t.font.SetCode(t.index, code) })
+	})
+
+	// Strona kodowa - zmiana tylko opisuje kody glifów, konwersja przestawia glify
+	t.codepageLabel = widget.NewLabel(T("codepage"))
+	t.codepageSelect = widget.NewSelect(codepageOptions(), func(name string) {
+		cp := codepageFromOption(name)
+		if t.font.Empty() || cp == t.font.Codepage {
+			return
+		}
+		t.font.Codepage = cp
+		t.updateGlyphInfo()
+		t.refreshSample()
+	})
+	t.codepageSelect.SetSelectedIndex(0)
+	t.convertBtn = widget.NewButton(T("codepageConvert"), func() {
+		if t.font.Empty() {
+			return
+		}
+		target := widget.NewSelect(codepageOptions(), nil)
+		target.SetSelectedIndex(0)
+		items := []*widget.FormItem{widget.NewFormItem(T("codepageTarget"), target)}
+		dialog.ShowForm(T("codepageConvert"), T("codepageConvertApply"), T("cancel"), items, func(ok bool) {
+			cp := codepageFromOption(target.Selected)
+			if !ok || cp == t.font.Codepage {
+				return
+			}
+			lost := 0
+			t.glyphOp(t.index, func() { lost = t.font.ConvertCodepage(cp) })
+			if lost > 0 {
+				dialog.ShowInformation(T("codepageConvert"), fmt.Sprintf(T("codepageLost"), lost), t.win)
+			}
+		}, t.win)
+	})
+
+	// Wybór głębi bitowej - zmiana przelicza poziomy jasności wszystkich glifów
+	depthNames := depthOptions()
+	t.depthSelect = widget.NewSelect(depthNames, func(name string) {
+		depth := depths[indexOf(depthNames, name)]
+		if t.font.Empty() || depth == max(t.font.Depth, 1) {
+			return
+		}
+		// paleta edytora zależy od głębi - zamykamy okno edycji; zmiana
+		// przelicza poziomy ze stratą, więc cały font trafia do UNDO
+		t.closeEditor()
+		t.history.PushFont(t.font)
+		t.font.SetDepth(depth)
+		t.updateSwap()
+		t.raster.Refresh()
+		t.refreshSample()
+	})
+	t.depthSelect.SetSelected(depthNames[0])
+	t.depthLabel = widget.NewLabel(T("depth"))
+
+	// Zamiana bajtów ikon RGB565 - zmienia tylko zapis, nie kolory (wpis UNDO)
+	t.swapCheck = widget.NewCheck(T("iconSwap"), func(on bool) {
+		if on == t.font.Swap {
+			return
+		}
+		t.history.PushFont(t.font)
+		t.font.Swap = on
+	})
+	t.swapCheck.Hide()
+
+	// Przełącznik fontu proporcjonalnego - włączenie liczy szerokości z tuszu,
+	// wyłączenie je usuwa, więc cały font trafia do UNDO
+	t.propCheck = widget.NewCheck(T("proportional"), func(on bool) {
+		if t.font.Empty() || on == t.font.Proportional() {
+			return
+		}
+		t.history.PushFont(t.font)
+		t.font.SetProportional(on)
+		if t.editor.isOpen() {
+			t.editor.refreshGrid()
+		}
+		t.raster.Refresh()
+		t.refreshSample()
+	})
+
+	// Podgląd przykładowego napisu z uwzględnieniem szerokości glifów
+	t.sampleEntry = widget.NewEntry()
+	t.sampleEntry.SetText("Hello, World 123")
+	t.sampleEntry.OnChanged = func(string) { t.refreshSample() }
+	t.sampleRaster = canvas.NewRasterWithPixels(func(x, y, wR, hR int) color.Color {
+		if t.font.Empty() {
+			return color.White
+		}
+		// linia bazowa napisu na wysokości Ascent - wyrównanie fontów o różnych komórkach
+		m := t.font.Metrics
+		level := textLevel(t.font, t.samplePlaced, x/sampleScale, y/sampleScale-m.Ascent+m.Baseline)
+		return t.font.PixelColor(level)
+	})
+
+	// przewijanie - kontrolki zakładki nie mieszczą się w niskim oknie
+	t.item = container.NewTabItem(t.title(), container.NewVScroll(container.NewVBox(
+		t.fileLabel,
+		t.glyphLabel,
+		t.slider,
+		container.NewHBox(t.insertBtn, t.duplicateBtn, t.deleteBtn, moveLeftBtn, moveRightBtn, t.renumberCheck),
+		container.NewBorder(nil, nil, t.codeLabel, t.setCodeBtn, t.codeEntry),
+		container.NewHBox(t.editBtn, t.copyGlyphBtn, t.pasteGlyphBtn, t.undoBtn, t.redoBtn),
+		t.scaleLabel,
+		scaleSlider,
+		container.NewCenter(t.raster),
+		container.NewHBox(t.depthLabel, t.depthSelect, t.swapCheck),
+		container.NewHBox(t.codepageLabel, t.codepageSelect, t.convertBtn),
+		t.propCheck,
+		t.sampleEntry,
+		container.NewHScroll(t.sampleRaster),
+	)))
+	return t
+}
+
+// title zwraca tytuł zakładki - nazwę pliku lub "Nowy"
+func (t *fontTab) title() string {
+	if t.fileName == "" {
+		return T("newTab")
+	}
+	return t.fileName
+}
+
+// setFont ustawia nowy font w zakładce i resetuje podgląd
+func (t *fontTab) setFont(f *Font, name string) {
+	t.closeEditor()
+	t.font = f
+	t.resetHistory()
+	t.fileName = name
+	t.index = 0
+	t.refresh()
+}
+
+// glyphOp wykonuje operację na liście glifów jako jeden wpis UNDO
+// i wybiera glif o indeksie index
+func (t *fontTab) glyphOp(index int, op func()) {
+	if t.font.Empty() {
+		return
+	}
+	t.closeEditor()
+	t.history.PushFont(t.font)
+	op()
+	t.index = index
+	t.refresh()
+}
+
+// updateGlyphInfo pokazuje indeks i znak aktualnego glifu oraz jego kod w polu edycji
+func (t *fontTab) updateGlyphInfo() {
+	text := T("glyph") + ": " + strconv.Itoa(t.index)
+	if !t.font.Empty() {
+		g := t.font.Glyph(t.index)
+		text += "  " + g.Label()
+		t.codeEntry.SetText(strconv.Itoa(g.Code()))
+	}
+	t.glyphLabel.SetText(text)
+}
+
+// resetHistory zaczyna nową historię UNDO / REDO zakładki
+func (t *fontTab) resetHistory() {
+	t.history = &History{OnFont: t.fontReshaped}
+}
+
+// fontReshaped zamyka okno edycji i odświeża zakładkę po zmianie całego fontu
+// (rozmiar komórki, liczba glifów) - także przy UNDO / REDO z okna edycji
+func (t *fontTab) fontReshaped() {
+	t.closeEditor()
+	t.refresh()
+}
+
+// refresh odświeża slider, podglądy i przełączniki zakładki po zmianie fontu
+func (t *fontTab) refresh() {
+	f := t.font
+	t.slider.Max = float64(max(0, f.Count()-1))
+	t.index = max(0, min(t.index, f.Count()-1))
+	t.slider.Value = float64(t.index)
+	t.slider.Refresh()
+	t.raster.SetMinSize(fyne.NewSize(float32(f.Width*t.scale), float32(f.Height*t.scale)))
+	t.raster.Refresh()
+	t.propCheck.SetChecked(f.Proportional())
+	t.depthSelect.SetSelectedIndex(max(0, slices.Index(depths, max(f.Depth, 1))))
+	t.updateSwap()
+	t.codepageSelect.SetSelectedIndex(slices.Index(codepageNames, f.Codepage) + 1)
+	t.refreshSample()
+	t.updateTexts()
+}
+
+// codepageOptions zwraca listę wyboru stron kodowych ("Unicode" na początku)
+func codepageOptions() []string {
+	return append([]string{T("codepageNone")}, codepageNames...)
+}
+
+// codepageFromOption zamienia pozycję listy wyboru na nazwę strony kodowej
+func codepageFromOption(name string) string {
+	if codepages[name] == nil {
+		return ""
+	}
+	return name
+}
+
+// updateSwap pokazuje przełącznik zamiany bajtów tylko dla ikon RGB565
+func (t *fontTab) updateSwap() {
+	t.swapCheck.SetChecked(t.font.Swap)
+	if t.font.RGB565() {
+		t.swapCheck.Show()
+	} else {
+		t.swapCheck.Hide()
+	}
+}
+
+// refreshSample rozmieszcza ponownie przykładowy napis (po zmianie tekstu lub szerokości)
+func (t *fontTab) refreshSample() {
+	if t.font.Empty() {
+		return
+	}
+	placed, width := layoutText(t.font, t.sampleEntry.Text)
+	t.samplePlaced = placed
+	m := t.font.Metrics
+	height := max(m.LineSpacing, m.Ascent+m.Descent, 1)
+	t.sampleRaster.SetMinSize(fyne.NewSize(float32(width*sampleScale), float32(height*sampleScale)))
+	t.sampleRaster.Refresh()
+}
+
+// metricsChanged odświeża linie w edytorze i podgląd napisu po zmianie metryk
+func (t *fontTab) metricsChanged() {
+	if t.editor.isOpen() {
+		t.editor.updateGuides()
+	}
+	t.refreshSample()
+}
+
+// closeEditor zamyka okno edycji zakładki, jeśli jest otwarte
+func (t *fontTab) closeEditor() {
+	if t.editor.isOpen() {
+		t.editor.win.Close()
+		t.editor.win = nil // okno uznane za zamknięte od razu (UNDO w trakcie obsługi przycisku)
+	}
+}
+
+// updateTexts odświeża teksty zakładki (po wczytaniu pliku lub zmianie języka)
+func (t *fontTab) updateTexts() {
+	if t.fileName == "" {
+		t.fileLabel.SetText(T("noFile"))
+	} else {
+		t.fileLabel.SetText(T("loaded") + t.fileName)
+	}
+	t.updateGlyphInfo()
+	t.insertBtn.SetText(T("glyphInsert"))
+	t.duplicateBtn.SetText(T("glyphDuplicate"))
+	t.deleteBtn.SetText(T("glyphDelete"))
+	t.codeLabel.SetText(T("glyphCode"))
+	t.setCodeBtn.SetText(T("glyphSetCode"))
+	t.renumberCheck.Text = T("glyphRenumber")
+	t.renumberCheck.Refresh()
+	t.codepageLabel.SetText(T("codepage"))
+	t.convertBtn.SetText(T("codepageConvert"))
+	selected := t.codepageSelect.SelectedIndex()
+	t.codepageSelect.SetOptions(codepageOptions())
+	t.codepageSelect.SetSelectedIndex(selected)
+	t.scaleLabel.SetText(T("scale") + ": " + strconv.Itoa(t.scale))
+	t.editBtn.SetText(T("editGlyph"))
+	t.copyGlyphBtn.SetText(T("glyphCopy"))
+	t.pasteGlyphBtn.SetText(T("glyphPaste"))
+	t.undoBtn.SetText(T("undo"))
+	t.redoBtn.SetText(T("redo"))
+	t.depthLabel.SetText(T("depth"))
+	t.propCheck.Text = T("proportional")
+	t.propCheck.Refresh()
+	t.swapCheck.Text = T("iconSwap")
+	t.swapCheck.Refresh()
+	t.item.Text = t.title()
+}
@@ -0,0 +1,207 @@
+/* ============================================================================
+
+    Narzędzia rysowania
+    Paleta narzędzi okna edycji: ołówek, linia, prostokąt (kontur lub
+    wypełniony), elipsa i wypełnianie obszaru
+    – shapePoints, ellipsePoints, glyphEditor.previewShape / drawShape /
+      floodFill, toolPalette
+
+    Kształt jest podglądany na siatce podczas przeciągania (glif bez
+    zmian) i rysowany po puszczeniu przycisku jako jeden wpis UNDO.
+    Prawy przycisk rysuje kształt gumką. Wypełnianie zamienia spójny
+    (4 sąsiadów) obszar pikseli o tym samym poziomie.
+
+=========================================================================== */
+
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Narzędzia rysowania
+const (
+	toolPen      = "pen"
+	toolLine     = "line"
+	toolRect     = "rect"
+	toolRectFill = "rectFill"
+	toolEllipse  = "ellipse"
+	toolFill     = "fill"
+	toolSelect   = "select" // zaznaczanie i przesuwanie fragmentu (clip.go)
+)
+
+var drawTools = []string{toolPen, toolLine, toolRect, toolRectFill, toolEllipse, toolFill, toolSelect}
+
+var drawTool = toolPen // wybrane narzędzie (zapamiętane między oknami edycji)
+
+// isShapeTool zwraca true dla narzędzi rysujących kształt od punktu do punktu
+func isShapeTool(tool string) bool {
+	return tool == toolLine || tool == toolRect || tool == toolRectFill || tool == toolEllipse
+}
+
+// shapePoints wywołuje fn dla każdego piksela kształtu rozpiętego między (x0, y0) i (x1, y1)
+func shapePoints(tool string, x0, y0, x1, y1 int, fn func(x, y int)) {
+	switch tool {
+	case toolLine:
+		linePoints(x0, y0, x1, y1, fn)
+	case toolRect:
+		linePoints(x0, y0, x1, y0, fn)
+		linePoints(x0, y1, x1, y1, fn)
+		linePoints(x0, y0, x0, y1, fn)
+		linePoints(x1, y0, x1, y1, fn)
+	case toolRectFill:
+		for y := min(y0, y1); y <= max(y0, y1); y++ {
+			for x := min(x0, x1); x <= max(x0, x1); x++ {
+				fn(x, y)
+			}
+		}
+	case toolEllipse:
+		ellipsePoints(x0, y0, x1, y1, fn)
+	}
+}
+
+// ellipsePoints wywołuje fn dla pikseli elipsy wpisanej w prostokąt (x0, y0) – (x1, y1)
+// (algorytm A. Zingla dla elipsy w prostokącie, także o parzystych wymiarach)
+func ellipsePoints(x0, y0, x1, y1 int, fn func(x, y int)) {
+	a, b := abs(x1-x0), abs(y1-y0)
+	if a == 0 || b == 0 {
+		linePoints(x0, y0, x1, y1, fn)
+		return
+	}
+	b1 := b & 1
+	dx, dy := 4*(1-a)*b*b, 4*(b1+1)*a*a
+	e := dx + dy + b1*a*a
+	if x0 > x1 {
+		x0, x1 = x1, x0
+	}
+	if y0 > y1 {
+		y0 = y1
+	}
+	y0 += (b + 1) / 2
+	y1 = y0 - b1
+	aa, bb := 8*a*a, 8*b*b
+	for x0 <= x1 {
+		fn(x1, y0)
+		fn(x0, y0)
+		fn(x0, y1)
+		fn(x1, y1)
+		e2 := 2 * e
+		if e2 <= dy {
+			y0++
+			y1--
+			dy += aa
+			e += dy
+		}
+		if e2 >= dx || 2*e > dy {
+			x0++
+			x1--
+			dx += bb
+			e += dx
+		}
+	}
+	// płaska elipsa (a = 1): dokończenie końców w pionie
+	for y0-y1 < b {
+		fn(x0-1, y0)
+		fn(x1+1, y0)
+		y0++
+		fn(x0-1, y1)
+		fn(x1+1, y1)
+		y1--
+	}
+}
+
+// previewShape pokazuje na siatce glif z narysowanym kształtem, nie zmieniając glifu
+func (ed *glyphEditor) previewShape(tool string, x0, y0, x1, y1, level int) {
+	f := ed.font
+	g := ed.glyph()
+	levels := g.Levels()
+	shapePoints(tool, x0, y0, x1, y1, func(x, y int) {
+		if x >= 0 && y >= 0 && x < f.Width && y < f.Height {
+			levels[y*f.Width+x] = uint16(level)
+		}
+	})
+	ed.showLevels(levels)
+}
+
+// showLevels pokazuje na siatce podane poziomy pikseli (podgląd bez zmiany glifu)
+func (ed *glyphEditor) showLevels(levels []uint16) {
+	f := ed.font
+	g := ed.glyph()
+	for y := 0; y < f.Height; y++ {
+		for x := 0; x < f.Width; x++ {
+			setRectColor(ed.rects[y][x], f, int(levels[y*f.Width+x]), !g.InAdvance(x))
+		}
+	}
+}
+
+// drawShape rysuje kształt w glifie jako jeden wpis UNDO
+func (ed *glyphEditor) drawShape(tool string, x0, y0, x1, y1, level int) {
+	f := ed.font
+	g := ed.glyph()
+	pushed := false
+	shapePoints(tool, x0, y0, x1, y1, func(x, y int) {
+		if x < 0 || y < 0 || x >= f.Width || y >= f.Height || g.Level(x, y) == level {
+			return
+		}
+		if !pushed {
+			ed.history.Push(g, ed.xShift, ed.yShift)
+			pushed = true
+		}
+		g.SetLevel(x, y, level)
+	})
+	ed.refreshGrid()
+}
+
+// floodFill wypełnia poziomem level spójny obszar pikseli o poziomie piksela (x, y)
+func (ed *glyphEditor) floodFill(x, y, level int) {
+	f := ed.font
+	g := ed.glyph()
+	if x < 0 || y < 0 || x >= f.Width || y >= f.Height {
+		return
+	}
+	target := g.Level(x, y)
+	if target == level {
+		return
+	}
+	ed.history.Push(g, ed.xShift, ed.yShift)
+	stack := [][2]int{{x, y}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		px, py := p[0], p[1]
+		if px < 0 || py < 0 || px >= f.Width || py >= f.Height || g.Level(px, py) != target {
+			continue
+		}
+		g.SetLevel(px, py, level)
+		stack = append(stack, [2]int{px + 1, py}, [2]int{px - 1, py}, [2]int{px, py + 1}, [2]int{px, py - 1})
+	}
+	ed.refreshGrid()
+}
+
+// toolPalette tworzy przyciski wyboru narzędzia rysowania
+func toolPalette() fyne.CanvasObject {
+	box := container.NewHBox()
+	var buttons []*widget.Button
+	for i, tool := range drawTools {
+		btn := widget.NewButton(T("tool_"+tool), nil)
+		btn.OnTapped = func() {
+			drawTool = tool
+			for j, b := range buttons {
+				if j == i {
+					b.Importance = widget.HighImportance
+				} else {
+					b.Importance = widget.MediumImportance
+				}
+				b.Refresh()
+			}
+		}
+		if tool == drawTool {
+			btn.Importance = widget.HighImportance
+		}
+		buttons = append(buttons, btn)
+		box.Add(btn)
+	}
+	return box
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// artFont tworzy jednoglifowy font 1bpp z rysunku ('#' = piksel zapalony)
+func artFont(art ...string) *Font {
+	rows := make([]uint16, len(art))
+	for y, line := range art {
+		for _, c := range line {
+			rows[y] <<= 1
+			if c == '#' {
+				rows[y] |= 1
+			}
+		}
+	}
+	return NewFont(len(art[0]), len(art), rows)
+}
+
+// shapeArt rysuje kształt w polu w×h jako wiersze '#' i '.'
+func shapeArt(tool string, w, h, x0, y0, x1, y1 int) []string {
+	f := NewFont(w, h, make([]uint16, h))
+	shapePoints(tool, x0, y0, x1, y1, func(x, y int) { f.Glyph(0).SetPixel(x, y, true) })
+	return glyphArt(f.Glyph(0))
+}
+
+func TestShapePoints(t *testing.T) {
+	tests := []struct {
+		name           string
+		tool           string
+		w, h           int
+		x0, y0, x1, y1 int
+		want           []string
+	}{
+		{"line", toolLine, 4, 2, 0, 0, 3, 1, []string{"##..", "..##"}},
+		{"rect reversed", toolRect, 4, 3, 3, 2, 0, 0, []string{"####", "#..#", "####"}},
+		{"filled rect", toolRectFill, 4, 3, 1, 0, 2, 2, []string{".##.", ".##.", ".##."}},
+		{"ellipse", toolEllipse, 5, 5, 0, 0, 4, 4, []string{".###.", "#...#", "#...#", "#...#", ".###."}},
+		{"even ellipse", toolEllipse, 4, 4, 0, 0, 3, 3, []string{".##.", "#..#", "#..#", ".##."}},
+		{"flat ellipse", toolEllipse, 4, 1, 0, 0, 3, 0, []string{"####"}},
+	}
+	for _, tt := range tests {
+		if got := shapeArt(tt.tool, tt.w, tt.h, tt.x0, tt.y0, tt.x1, tt.y1); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Kształt widać w podglądzie w trakcie przeciągania, do glifu trafia po puszczeniu
+// przycisku jako jeden wpis UNDO
+func TestDrawShape(t *testing.T) {
+	f := NewFont(4, 3, make([]uint16, 3))
+	ed, p := testEditor(t, f)
+	drawTool = toolRect
+
+	p.MouseDown(&desktop.MouseEvent{PointEvent: fyne.PointEvent{Position: cellPos(ed, 0, 0)}})
+	p.Dragged(&fyne.DragEvent{PointEvent: fyne.PointEvent{Position: cellPos(ed, 3, 2)}})
+	if !slices.Equal(f.Data, []uint16{0, 0, 0}) || ed.rects[1][0].FillColor != f.PixelColor(1) {
+		t.Fatalf("preview: rows %04b, rect (0, 1) %v", f.Data, ed.rects[1][0].FillColor)
+	}
+	p.DragEnd()
+	if got := glyphArt(f.Glyph(0)); !slices.Equal(got, []string{"####", "#..#", "####"}) {
+		t.Fatalf("got %q", got)
+	}
+	if len(ed.history.undoStack) != 1 {
+		t.Errorf("%d undo entries, want 1", len(ed.history.undoStack))
+	}
+}
+
+// Wypełnianie obejmuje spójny (4-kierunkowo) obszar o poziomie klikniętego piksela
+func TestFloodFill(t *testing.T) {
+	art := []string{
+		"#####",
+		"#..#.",
+		"#.#..",
+		"##...",
+	}
+	rows := artFont(art...).Data
+	tests := []struct {
+		name  string
+		x, y  int
+		level int
+		want  []string
+		undo  int
+	}{
+		{"inside", 1, 1, 1, []string{"#####", "####.", "###..", "##..."}, 1},
+		{"outside, diagonal gap", 4, 3, 1, []string{"#####", "#..##", "#.###", "#####"}, 1},
+		{"erase outline", 0, 0, 0, []string{".....", ".....", "..#..", "....."}, 1},
+		{"same level", 1, 1, 0, art, 0},
+		{"outside cell", 7, 1, 1, art, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFont(5, 4, slices.Clone(rows))
+			ed, _ := testEditor(t, f)
+			ed.floodFill(tt.x, tt.y, tt.level)
+			if got := glyphArt(f.Glyph(0)); !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if len(ed.history.undoStack) != tt.undo {
+				t.Errorf("%d undo entries, want %d", len(ed.history.undoStack), tt.undo)
+			}
+			ed.history.Undo(f, 0, 0)
+			if !slices.Equal(f.Data, rows) {
+				t.Errorf("after undo: rows %05b", f.Data)
+			}
+		})
+	}
+}
+
+// Wypełnianie w odcieniach szarości: obszar jednego poziomu, inne poziomy są granicą
+func TestFloodFillGray(t *testing.T) {
+	f := NewGrayFont(4, 1, 2, levelsFrom("1121"))
+	ed, _ := testEditor(t, f)
+	ed.floodFill(0, 0, 3)
+	if got := f.Glyph(0).Levels(); !slices.Equal(got, levelsFrom("3321")) {
+		t.Errorf("levels %v", got)
+	}
+}
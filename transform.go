@@ -0,0 +1,182 @@
+/* ============================================================================
+
+    Przekształcenia glifów
+    Odbicie w poziomie i w pionie, obrót o 90°, negatyw i przesunięcie
+    o piksel z zawijaniem – dla całego glifu, zaznaczenia w oknie edycji
+    lub zakresu glifów z głównego okna
+    – Glyph.Transform, Font.Transform, transformButtons, transformDialog
+
+    Obrót wymaga kwadratowego obszaru (komórka lub zaznaczenie).
+    Negatyw odwraca poziom piksela (MaxLevel - poziom; RGB565: kolor
+    odwrócony bitowo). Odbicie całego glifu proporcjonalnego odbija też
+    jego odstęp z lewej, obrót liczy szerokość z tuszu na nowo.
+
+=========================================================================== */
+
+package main
+
+import (
+	"errors"
+	"image"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Przekształcenia glifu
+const (
+	xfMirror     = "mirror"    // odbicie w poziomie (lewo - prawo)
+	xfFlip       = "flip"      // odbicie w pionie (góra - dół)
+	xfRotateCW   = "rotateCW"  // obrót o 90° w prawo
+	xfRotateCCW  = "rotateCCW" // obrót o 90° w lewo
+	xfInvert     = "invert"    // negatyw
+	xfShiftLeft  = "shiftLeft" // przesunięcia o piksel z zawijaniem
+	xfShiftRight = "shiftRight"
+	xfShiftUp    = "shiftUp"
+	xfShiftDown  = "shiftDown"
+)
+
+var transforms = []string{
+	xfMirror, xfFlip, xfRotateCW, xfRotateCCW, xfInvert,
+	xfShiftLeft, xfShiftRight, xfShiftUp, xfShiftDown,
+}
+
+// transformSource zwraca współrzędne piksela (w obszarze w×h), który po
+// przekształceniu trafia na pozycję (x, y)
+func transformSource(op string, x, y, w, h int) (int, int) {
+	switch op {
+	case xfMirror:
+		return w - 1 - x, y
+	case xfFlip:
+		return x, h - 1 - y
+	case xfRotateCW:
+		return y, w - 1 - x
+	case xfRotateCCW:
+		return h - 1 - y, x
+	case xfShiftLeft:
+		return (x + 1) % w, y
+	case xfShiftRight:
+		return (x + w - 1) % w, y
+	case xfShiftUp:
+		return x, (y + 1) % h
+	case xfShiftDown:
+		return x, (y + h - 1) % h
+	}
+	return x, y
+}
+
+// Transform przekształca prostokąt r glifu (r = cała komórka: także metryki)
+func (g Glyph) Transform(r image.Rectangle, op string) error {
+	f := g.Font
+	w, h := r.Dx(), r.Dy()
+	if (op == xfRotateCW || op == xfRotateCCW) && w != h {
+		return errors.New(T("xfNotSquare"))
+	}
+	src := copyRegion(g, r).Glyph(0)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			level := src.Level(transformSource(op, x, y, w, h))
+			if op == xfInvert {
+				level = f.MaxLevel() - level
+			}
+			g.SetLevel(r.Min.X+x, r.Min.Y+y, level)
+		}
+	}
+
+	if f.Proportional() && r == glyphBounds(f) {
+		switch op {
+		case xfMirror:
+			g.SetMetrics(f.Width-g.Bearing()-g.Advance(), g.Advance())
+		case xfRotateCW, xfRotateCCW:
+			g.AutoWidth()
+		}
+	}
+	return nil
+}
+
+// Transform przekształca całe glify o podanych indeksach
+func (f *Font) Transform(indices []int, op string) error {
+	if (op == xfRotateCW || op == xfRotateCCW) && f.Width != f.Height {
+		return errors.New(T("xfNotSquare"))
+	}
+	for _, i := range indices {
+		if err := f.Glyph(i).Transform(glyphBounds(f), op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transformButtons tworzy przyciski przekształceń okna edycji (zaznaczenie lub cały glif)
+func (ed *glyphEditor) transformButtons() fyne.CanvasObject {
+	box := container.NewHBox()
+	for _, op := range transforms {
+		box.Add(widget.NewButton(T("xf_"+op), func() {
+			r := ed.sel
+			if r.Empty() {
+				r = glyphBounds(ed.font)
+			}
+			if (op == xfRotateCW || op == xfRotateCCW) && r.Dx() != r.Dy() {
+				dialog.ShowError(errors.New(T("xfNotSquare")), ed.win)
+				return
+			}
+			g := ed.glyph()
+			ed.history.Push(g, ed.xShift, ed.yShift)
+			_ = g.Transform(r, op)
+			ed.refreshGrid()
+		}))
+	}
+	return box
+}
+
+// Wywoływane przy kliknięciu "Przekształcenia" - przekształca zakres glifów
+// jako jeden wpis UNDO; onApply odświeża zakładkę
+func transformDialog(w fyne.Window, f *Font, history *History, index int, onApply func()) {
+	if f.Empty() {
+		dialog.ShowInformation(T("noData"), T("loadFirst"), w)
+		return
+	}
+
+	opNames := make([]string, len(transforms))
+	for i, op := range transforms {
+		opNames[i] = T("xf_" + op)
+	}
+	opSelect := widget.NewSelect(opNames, nil)
+	opSelect.SetSelected(opNames[0])
+	rangeEntry := widget.NewEntry()
+	rangeEntry.SetPlaceHolder(T("subsetHint_range"))
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(T("xfOperation"), opSelect),
+		widget.NewFormItem(T("xfRange"), rangeEntry),
+	}
+	dialog.ShowForm(T("transform"), T("xfApply"), T("cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		// puste pole - tylko bieżący glif
+		indices := []int{index}
+		if strings.TrimSpace(rangeEntry.Text) != "" {
+			codes, err := parseCharRange(rangeEntry.Text)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if indices, _ = subsetIndices(f, codes); len(indices) == 0 {
+				dialog.ShowError(errors.New(T("subsetEmpty")), w)
+				return
+			}
+		}
+		op := transforms[indexOf(opNames, opSelect.Selected)]
+		if (op == xfRotateCW || op == xfRotateCCW) && f.Width != f.Height {
+			dialog.ShowError(errors.New(T("xfNotSquare")), w)
+			return
+		}
+		history.PushFont(f)
+		_ = f.Transform(indices, op)
+		onApply()
+	}, w)
+}
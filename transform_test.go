@@ -0,0 +1,156 @@
+package main
+
+import (
+	"image"
+	"slices"
+	"testing"
+)
+
+// levelsFrom zamienia rysunek (wiersze cyfr poziomów) na poziomy pikseli
+func levelsFrom(art ...string) []uint16 {
+	var levels []uint16
+	for _, line := range art {
+		for _, c := range line {
+			levels = append(levels, uint16(c-'0'))
+		}
+	}
+	return levels
+}
+
+func TestTransformSource(t *testing.T) {
+	// obszar 3×2: (x, y) -> piksel źródłowy
+	tests := []struct {
+		op   string
+		x, y int
+		sx   int
+		sy   int
+	}{
+		{xfMirror, 0, 0, 2, 0},
+		{xfMirror, 2, 1, 0, 1},
+		{xfFlip, 1, 0, 1, 1},
+		{xfShiftLeft, 2, 0, 0, 0},
+		{xfShiftRight, 0, 1, 2, 1},
+		{xfShiftUp, 1, 1, 1, 0},
+		{xfShiftDown, 1, 0, 1, 1},
+		{xfInvert, 2, 1, 2, 1},
+	}
+	for _, tt := range tests {
+		sx, sy := transformSource(tt.op, tt.x, tt.y, 3, 2)
+		if sx != tt.sx || sy != tt.sy {
+			t.Errorf("%s (%d, %d): source (%d, %d), want (%d, %d)", tt.op, tt.x, tt.y, sx, sy, tt.sx, tt.sy)
+		}
+	}
+}
+
+func TestGlyphTransform(t *testing.T) {
+	src := levelsFrom(
+		"310",
+		"200",
+		"000",
+	)
+	tests := []struct {
+		op   string
+		want []uint16
+	}{
+		{xfMirror, levelsFrom("013", "002", "000")},
+		{xfFlip, levelsFrom("000", "200", "310")},
+		{xfRotateCW, levelsFrom("023", "001", "000")},
+		{xfRotateCCW, levelsFrom("000", "100", "320")},
+		{xfInvert, levelsFrom("023", "133", "333")},
+		{xfShiftLeft, levelsFrom("103", "002", "000")},
+		{xfShiftRight, levelsFrom("031", "020", "000")},
+		{xfShiftUp, levelsFrom("200", "000", "310")},
+		{xfShiftDown, levelsFrom("000", "310", "200")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.op, func(t *testing.T) {
+			f := NewGrayFont(3, 3, 2, slices.Clone(src))
+			if err := f.Transform([]int{0}, tt.op); err != nil {
+				t.Fatal(err)
+			}
+			if got := f.Glyph(0).Levels(); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Przekształcenie złożone z przekształceniem odwrotnym wraca do stanu wyjściowego (1bpp)
+func TestTransformInverse(t *testing.T) {
+	rows := []uint16{0x1, 0x3, 0x6, 0xC}
+	sequences := [][]string{
+		{xfRotateCW, xfRotateCW, xfRotateCW, xfRotateCW},
+		{xfRotateCW, xfRotateCCW},
+		{xfMirror, xfMirror},
+		{xfFlip, xfFlip},
+		{xfInvert, xfInvert},
+		{xfShiftLeft, xfShiftRight},
+		{xfShiftUp, xfShiftUp, xfShiftUp, xfShiftUp},
+	}
+	for _, ops := range sequences {
+		f := NewFont(4, 4, slices.Clone(rows))
+		for _, op := range ops {
+			if err := f.Transform([]int{0}, op); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if !slices.Equal(f.Data, rows) {
+			t.Errorf("%v: got %X, want %X", ops, f.Data, rows)
+		}
+	}
+}
+
+// Zaznaczenie: przekształcany jest tylko prostokąt, reszta glifu bez zmian
+func TestGlyphTransformSelection(t *testing.T) {
+	f := NewFont(4, 3, []uint16{0b1000, 0b0100, 0b0001})
+	if err := f.Glyph(0).Transform(image.Rect(0, 0, 2, 2), xfMirror); err != nil {
+		t.Fatal(err)
+	}
+	want := []uint16{0b0100, 0b1000, 0b0001}
+	if !slices.Equal(f.Data, want) {
+		t.Errorf("got %04b, want %04b", f.Data, want)
+	}
+	if err := f.Glyph(0).Transform(image.Rect(0, 0, 3, 2), xfRotateCW); err == nil {
+		t.Error("rotating a non-square selection: expected error")
+	}
+	if err := f.Transform([]int{0}, xfRotateCCW); err == nil {
+		t.Error("rotating a non-square cell: expected error")
+	}
+}
+
+// Odbicie glifu proporcjonalnego odbija odstęp z lewej, obrót liczy szerokość z tuszu
+func TestTransformProportional(t *testing.T) {
+	f := NewFont(5, 5, []uint16{0b11000, 0b11000, 0, 0, 0})
+	f.Glyph(0).SetMetrics(0, 3)
+
+	if err := f.Transform([]int{0}, xfMirror); err != nil {
+		t.Fatal(err)
+	}
+	if g := f.Glyph(0); g.Bearing() != 2 || g.Advance() != 3 {
+		t.Errorf("mirror: bearing %d advance %d, want 2 and 3", g.Bearing(), g.Advance())
+	}
+
+	if err := f.Transform([]int{0}, xfRotateCW); err != nil {
+		t.Fatal(err)
+	}
+	// tusz przy prawej krawędzi - odstęp za tuszem przycięty do komórki
+	left, right, _ := f.Glyph(0).InkBounds()
+	if g := f.Glyph(0); g.Bearing() != left || g.Advance() != min(right-left+1+autoSpacing, f.Width-left) {
+		t.Errorf("rotate: bearing %d advance %d, ink %d..%d", g.Bearing(), g.Advance(), left, right)
+	}
+}
+
+// Negatyw ikony RGB565 odwraca kolor bitowo
+func TestTransformInvertRGB565(t *testing.T) {
+	f := NewIconFont(2, 1, []uint16{0xF800, 0x0000}, false)
+	if err := f.Transform([]int{0}, xfInvert); err != nil {
+		t.Fatal(err)
+	}
+	want := []uint16{0x07FF, 0xFFFF}
+	if !slices.Equal(f.Pixels, want) {
+		t.Errorf("got %04X, want %04X", f.Pixels, want)
+	}
+	if err := f.Transform([]int{0}, xfRotateCW); err == nil {
+		t.Error("rotating a 2x1 icon: expected error")
+	}
+}
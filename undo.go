@@ -4,68 +4,121 @@
     Wszystkie funkcje związane z oknem edycji pojedynczego znaku
     – umozliwiają cofanie i ponawianie zmian w edycji pikseli siatki oraz
       przesunięć sliderem X i Y
+    – operacje na całym foncie (zmiana rozmiaru komórki) zapisują kopię
+      całego fontu (PushFont) na tym samym stosie
 
 =========================================================================== */
 
 package main
 
-// Stosy UNDO / REDO
-var undoStack []GlyphState
-var redoStack []GlyphState
+// History przechowuje stosy UNDO / REDO jednego fontu
+type History struct {
+	undoStack []GlyphState
+	redoStack []GlyphState
+
+	OnFont func() // wywoływane po przywróceniu stanu całego fontu
+}
 
 type GlyphState struct {
+	Index   int
 	Data    []uint16
 	OffsetX int
 	OffsetY int
+	Bearing int // metryki fontu proporcjonalnego
+	Advance int
+	Levels  []uint16 // wartości pikseli fontu w odcieniach szarości / RGB565 (nil dla 1bpp)
+	Font    *Font    // kopia całego fontu (operacje na całym foncie), nil = stan glifu
 }
 
-// Zapisuje aktualny stan glifu i offsetów
-func snapshotState(index, h int) GlyphState {
-	snap := make([]uint16, h)
-	copy(snap, fontData[index*h:index*h+h])
+// Zapisuje aktualny stan glifu, offsetów i szerokości
+func snapshotState(g Glyph, xShift, yShift int) GlyphState {
+	snap := make([]uint16, g.Font.Height)
+	copy(snap, g.Rows())
 	return GlyphState{
+		Index:   g.Index,
 		Data:    snap,
 		OffsetX: xShift,
 		OffsetY: yShift,
+		Bearing: g.Bearing(),
+		Advance: g.Advance(),
+		Levels:  levelsOf(g),
+	}
+}
+
+// levelsOf zwraca kopię poziomów glifu dla fontu w odcieniach szarości, nil dla 1bpp
+func levelsOf(g Glyph) []uint16 {
+	if g.Font.Depth <= 1 {
+		return nil
 	}
+	return g.Levels()
 }
 
-// Przywraca stan glifu i offsetów
-func restoreState(index, h int, state GlyphState) {
-	copy(fontData[index*h:index*h+h], state.Data)
-	xShift = state.OffsetX
-	yShift = state.OffsetY
+// snapshotFor zapisuje stan tego samego rodzaju co state (glif lub cały font)
+func snapshotFor(f *Font, state GlyphState, xShift, yShift int) GlyphState {
+	if state.Font != nil {
+		return GlyphState{Font: f.Clone(), OffsetX: xShift, OffsetY: yShift}
+	}
+	return snapshotState(f.Glyph(state.Index), xShift, yShift)
+}
+
+// Przywraca stan glifu lub całego fontu i zwraca zapisane offsety
+func (h *History) restoreState(f *Font, state GlyphState) (int, int) {
+	if state.Font != nil {
+		*f = *state.Font
+		if h.OnFont != nil {
+			h.OnFont()
+		}
+		return state.OffsetX, state.OffsetY
+	}
+	g := f.Glyph(state.Index)
+	copy(g.Rows(), state.Data)
+	if state.Levels != nil && f.Depth > 1 {
+		g.SetLevels(state.Levels)
+	}
+	if f.Proportional() {
+		g.SetMetrics(state.Bearing, state.Advance)
+	}
+	return state.OffsetX, state.OffsetY
 }
 
 // zapisywanie stanu aktualnie edytowanego glifu do stosu UNDO
-func pushUndo(index int) {
-	if glyphH == 0 {
+func (h *History) Push(g Glyph, xShift, yShift int) {
+	if g.Font.Empty() {
+		return
+	}
+	h.undoStack = append(h.undoStack, snapshotState(g, xShift, yShift))
+	h.redoStack = nil
+}
+
+// PushFont zapisuje kopię całego fontu przed operacją zmieniającą wszystkie glify
+func (h *History) PushFont(f *Font) {
+	if f.Empty() {
 		return
 	}
-	undoStack = append(undoStack, snapshotState(index, glyphH))
-	redoStack = nil
+	h.undoStack = append(h.undoStack, GlyphState{Font: f.Clone()})
+	h.redoStack = nil
 }
 
-// Funkcja Undo
-func undo(index int) bool {
-	if len(undoStack) == 0 {
-		return false
+// Funkcja Undo - zwraca przywrócone offsety
+func (h *History) Undo(f *Font, xShift, yShift int) (int, int, bool) {
+	if len(h.undoStack) == 0 {
+		return xShift, yShift, false
 	}
-	last := undoStack[len(undoStack)-1]
-	undoStack = undoStack[:len(undoStack)-1]
-	redoStack = append(redoStack, snapshotState(index, glyphH))
-	restoreState(index, glyphH, last)
-	return true
+	last := h.undoStack[len(h.undoStack)-1]
+	h.undoStack = h.undoStack[:len(h.undoStack)-1]
+	h.redoStack = append(h.redoStack, snapshotFor(f, last, xShift, yShift))
+	x, y := h.restoreState(f, last)
+	return x, y, true
 }
 
-// Funkcja redo
-func redo(index int) bool {
-	if len(redoStack) == 0 {
-		return false
+// Funkcja redo - zwraca przywrócone offsety
+func (h *History) Redo(f *Font, xShift, yShift int) (int, int, bool) {
+	if len(h.redoStack) == 0 {
+		return xShift, yShift, false
 	}
-	last := redoStack[len(redoStack)-1]
-	redoStack = redoStack[:len(redoStack)-1]
-	undoStack = append(undoStack, snapshotState(index, glyphH))
-	restoreState(index, glyphH, last)
-	return true
+	last := h.redoStack[len(h.redoStack)-1]
+	h.redoStack = h.redoStack[:len(h.redoStack)-1]
+	h.undoStack = append(h.undoStack, snapshotFor(f, last, xShift, yShift))
+	x, y := h.restoreState(f, last)
+	return x, y, true
 }
@@ -0,0 +1,247 @@
+/* ============================================================================
+
+    Font proporcjonalny
+    Szerokość (advance) i odstęp z lewej (bearing) każdego glifu
+    – Glyph.Advance / Bearing / SetMetrics, Font.AutoWidths,
+      layoutText (podgląd napisu), edgeMarker (przeciągany znacznik krawędzi)
+
+    Glif zajmuje kolumny komórki od Bearing do Bearing+Advance-1;
+    przy rysowaniu te kolumny trafiają na pozycję kursora, po czym
+    kursor przesuwa się o Advance. Font o stałej szerokości ma
+    Advance = Width i Bearing = 0 dla wszystkich znaków.
+
+=========================================================================== */
+
+package main
+
+import (
+	"image/color"
+	"regexp"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Odstęp dodawany za tuszem przy automatycznym liczeniu szerokości
+const autoSpacing = 1
+
+// Tabele szerokości / odstępów w źródłach: FONT_8x16_widths[], FONT_8X16_WIDTHS, Font8x16Widths, WIDTHS
+var metricTableRE = regexp.MustCompile(`(?i)\b\w*?(widths|bearings)\b[^=\n,]*=\s*(?:&\s*)?(?:\[\]\s*(?:uint8|byte)\s*)?[\[\({]`)
+
+// Proportional zwraca true, jeśli font ma własne szerokości glifów
+func (f *Font) Proportional() bool {
+	return f != nil && f.Advance != nil
+}
+
+// SetProportional włącza szerokości liczone z tuszu lub wraca do stałej szerokości
+func (f *Font) SetProportional(on bool) {
+	if !on {
+		f.Advance, f.Bearing = nil, nil
+		return
+	}
+	if !f.Proportional() {
+		f.AutoWidths()
+	}
+}
+
+// AutoWidths liczy szerokości i odstępy wszystkich glifów z tuszu
+func (f *Font) AutoWidths() {
+	for _, g := range f.Glyphs() {
+		g.AutoWidth()
+	}
+}
+
+// Advance zwraca szerokość glifu (przesunięcie kursora)
+func (g Glyph) Advance() int {
+	if !g.Font.Proportional() {
+		return g.Font.Width
+	}
+	return g.Font.Advance[g.Index]
+}
+
+// Bearing zwraca liczbę pomijanych kolumn z lewej strony komórki
+func (g Glyph) Bearing() int {
+	if !g.Font.Proportional() {
+		return 0
+	}
+	return g.Font.Bearing[g.Index]
+}
+
+// SetMetrics ustawia odstęp z lewej i szerokość glifu (font staje się proporcjonalny)
+func (g Glyph) SetMetrics(bearing, advance int) {
+	f := g.Font
+	if !f.Proportional() {
+		f.Advance = make([]int, f.Count())
+		f.Bearing = make([]int, f.Count())
+		for i := range f.Advance {
+			f.Advance[i] = f.Width
+		}
+	}
+	bearing = max(0, min(bearing, f.Width-1))
+	advance = max(1, min(advance, f.Width-bearing))
+	f.Bearing[g.Index] = bearing
+	f.Advance[g.Index] = advance
+}
+
+// InkBounds zwraca pierwszą i ostatnią kolumnę z zapalonym pikselem
+func (g Glyph) InkBounds() (left, right int, ok bool) {
+	var ink uint16
+	for _, row := range g.Rows() {
+		ink |= row
+	}
+	if ink == 0 {
+		return 0, 0, false
+	}
+	w := g.Font.Width
+	left, right = -1, 0
+	for x := 0; x < w; x++ {
+		if (ink>>(w-1-x))&1 != 0 {
+			if left < 0 {
+				left = x
+			}
+			right = x
+		}
+	}
+	return left, right, true
+}
+
+// AutoWidth ustawia szerokość glifu na szerokość tuszu + odstęp;
+// pusty glif (spacja) dostaje połowę szerokości komórki
+func (g Glyph) AutoWidth() {
+	left, right, ok := g.InkBounds()
+	if !ok {
+		g.SetMetrics(0, max(1, g.Font.Width/2))
+		return
+	}
+	g.SetMetrics(left, right-left+1+autoSpacing)
+}
+
+// InAdvance sprawdza, czy kolumna x należy do szerokości glifu
+func (g Glyph) InAdvance(x int) bool {
+	b := g.Bearing()
+	return x >= b && x < b+g.Advance()
+}
+
+// exportWidths zwraca szerokości zapisywanych glifów
+func exportWidths(f *Font, opt exportOptions) []int {
+	var vals []int
+	for _, i := range exportIndices(f, opt) {
+		vals = append(vals, f.Glyph(i).Advance())
+	}
+	return vals
+}
+
+// exportBearings zwraca odstępy z lewej zapisywanych glifów
+func exportBearings(f *Font, opt exportOptions) []int {
+	var vals []int
+	for _, i := range exportIndices(f, opt) {
+		vals = append(vals, f.Glyph(i).Bearing())
+	}
+	return vals
+}
+
+// parseMetricTables odczytuje tabele szerokości i odstępów zapisane przy eksporcie
+// i ustawia je w foncie, jeśli liczba wpisów zgadza się z liczbą glifów
+func parseMetricTables(f *Font, src string) {
+	var widths, bearings []uint32
+	for _, m := range metricTableRE.FindAllStringSubmatchIndex(src, -1) {
+		open := m[1] - 1
+		values, _, err := parseInitializer(initializerBody(src, open, src[open] == '('))
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(src[m[2]:m[3]], "widths") {
+			widths = values
+		} else {
+			bearings = values
+		}
+	}
+	if len(widths) != f.Count() {
+		return
+	}
+	for i, g := range f.Glyphs() {
+		b := 0
+		if len(bearings) == len(widths) {
+			b = int(bearings[i])
+		}
+		g.SetMetrics(b, int(widths[i]))
+	}
+}
+
+// placedGlyph to glif ustawiony w podglądzie napisu na pozycji x
+type placedGlyph struct {
+	index int
+	x     int
+}
+
+// layoutText rozmieszcza znaki napisu zgodnie z szerokościami glifów
+// i zwraca pozycje oraz całkowitą szerokość w pikselach
+func layoutText(f *Font, text string) ([]placedGlyph, int) {
+	var placed []placedGlyph
+	cursor := 0
+	for _, r := range text {
+		i := f.IndexOfRune(r)
+		if i < 0 {
+			cursor += f.Width / 2
+			continue
+		}
+		g := f.Glyph(i)
+		placed = append(placed, placedGlyph{index: i, x: cursor - g.Bearing()})
+		cursor += g.Advance()
+	}
+	return placed, cursor
+}
+
+// textLevel zwraca poziom jasności piksela (x, y) napisu rozmieszczonego przez layoutText
+func textLevel(f *Font, placed []placedGlyph, x, y int) int {
+	level := 0
+	for _, p := range placed {
+		g := f.Glyph(p.index)
+		gx := x - p.x
+		if g.InAdvance(gx) {
+			level = max(level, g.Level(gx, y))
+		}
+	}
+	return level
+}
+
+// edgeMarker to pionowy znacznik krawędzi glifu przeciągany myszą w oknie edycji
+type edgeMarker struct {
+	widget.BaseWidget
+	bar    *canvas.Rectangle
+	onDrag func(x float32) // pozycja X w układzie siatki
+	onEnd  func()
+}
+
+// newEdgeMarker tworzy znacznik w podanym kolorze
+func newEdgeMarker(c color.Color, onDrag func(x float32), onEnd func()) *edgeMarker {
+	m := &edgeMarker{bar: canvas.NewRectangle(c), onDrag: onDrag, onEnd: onEnd}
+	m.ExtendBaseWidget(m)
+	return m
+}
+
+func (m *edgeMarker) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(m.bar)
+}
+
+// Dragged przelicza pozycję kursora na układ siatki
+func (m *edgeMarker) Dragged(e *fyne.DragEvent) {
+	m.onDrag(m.Position().X + e.Position.X)
+}
+
+func (m *edgeMarker) DragEnd() {
+	m.onEnd()
+}
+
+// MouseDown / MouseUp przejmują kliknięcie, żeby nie malowało pod znacznikiem
+func (m *edgeMarker) MouseDown(*desktop.MouseEvent) {}
+
+func (m *edgeMarker) MouseUp(*desktop.MouseEvent) {}
+
+// Cursor pokazuje kursor zmiany rozmiaru nad znacznikiem
+func (m *edgeMarker) Cursor() desktop.Cursor {
+	return desktop.HResizeCursor
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Włączenie i wyłączenie fontu proporcjonalnego da się cofnąć wraz z szerokościami
+func TestProportionalUndo(t *testing.T) {
+	f := NewFont(5, 2, []uint16{0b11000, 0, 0b00110, 0})
+	f.SetProportional(true)
+	f.Glyph(0).SetMetrics(1, 4)
+	h := &History{}
+
+	h.PushFont(f)
+	f.SetProportional(false)
+	if f.Proportional() {
+		t.Fatal("still proportional")
+	}
+	if _, _, ok := h.Undo(f, 0, 0); !ok {
+		t.Fatal("nothing to undo")
+	}
+	if !slices.Equal(f.Bearing, []int{1, 2}) || f.Advance[0] != 4 {
+		t.Errorf("after undo: bearings %v advances %v", f.Bearing, f.Advance)
+	}
+	if _, _, ok := h.Redo(f, 0, 0); !ok || f.Proportional() {
+		t.Errorf("redo: proportional %t", f.Proportional())
+	}
+}
+
+func TestAutoWidth(t *testing.T) {
+	tests := []struct {
+		name         string
+		row          uint16
+		bearing, adv int
+	}{
+		{"centre", 0b00110, 2, 3},
+		{"left edge", 0b10000, 0, 2},
+		{"right edge clamped", 0b00011, 3, 2},
+		{"blank", 0, 0, 2},
+	}
+	for _, tt := range tests {
+		f := NewFont(5, 1, []uint16{tt.row})
+		f.SetProportional(true)
+		if g := f.Glyph(0); g.Bearing() != tt.bearing || g.Advance() != tt.adv {
+			t.Errorf("%s: bearing %d advance %d, want %d %d", tt.name, g.Bearing(), g.Advance(), tt.bearing, tt.adv)
+		}
+	}
+}
+
+// Napis w podglądzie: każdy glif przesunięty o własny odstęp, kursor o szerokość;
+// znak spoza fontu zostawia pół komórki odstępu
+func TestLayoutText(t *testing.T) {
+	f := NewFont(5, 1, []uint16{0b01100, 0b00010})
+	f.FirstChar = 'a'
+	f.SetProportional(true) // 'a': 1/3, 'b': 3/2
+	placed, width := layoutText(f, "ab?a")
+	want := []placedGlyph{{0, -1}, {1, 0}, {0, 6}}
+	if !slices.Equal(placed, want) || width != 10 {
+		t.Errorf("got %v width %d, want %v width 10", placed, width, want)
+	}
+	var line []int
+	for x := range width {
+		line = append(line, textLevel(f, placed, x, 0))
+	}
+	if !slices.Equal(line, []int{1, 1, 0, 1, 0, 0, 0, 1, 1, 0}) {
+		t.Errorf("pixels %v", line)
+	}
+}
+
+// Szerokości i odstępy wracają z pliku w każdym formacie, także dla podzbioru
+func TestProportionalRoundTrip(t *testing.T) {
+	f := testFont(t, 8, 8, 1)
+	f.SetProportional(true)
+	f.Glyph(f.Index('i')).SetMetrics(2, 3)
+	roundTrip(t, f, exportOptions{})
+
+	indices, _ := subsetIndices(f, charsOfText("il"))
+	for _, format := range exportFormats {
+		got, err := parseHeaderWithSize(strings.NewReader(generateFontSource(f, format, exportOptions{Glyphs: indices})))
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if g := got.Glyph(0); g.Bearing() != 2 || g.Advance() != 3 {
+			t.Errorf("%s: 'i' %d/%d, want 2/3", format, g.Bearing(), g.Advance())
+		}
+	}
+}